@@ -6,57 +6,210 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
 	"github.com/hadnu/cicd-secret-detector/internal/detector"
+	"github.com/hadnu/cicd-secret-detector/internal/gitscanner"
 	"github.com/hadnu/cicd-secret-detector/internal/reporter"
 	"github.com/hadnu/cicd-secret-detector/internal/scanner"
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+	"github.com/hadnu/cicd-secret-detector/internal/verifier"
+)
+
+// Exit codes distinguish a confirmed-live secret from one that merely matched a
+// pattern, so CI can choose to fail harder on the former.
+const (
+	exitOK                = 0
+	exitUnverifiedMatch   = 1
+	exitVerifiedLiveFound = 2
 )
 
 func main() {
-	if err := run(); err != nil {
+	var (
+		code int
+		err  error
+	)
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		code, err = runWatch(os.Args[2:])
+	} else {
+		code, err = run()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
 	}
+	os.Exit(code)
 }
 
-func run() error {
+func run() (int, error) {
 	var (
-		dirArg = flag.String("dir", ".", "Directory to scan")
-		format = flag.String("format", "text", "Output format (text, json)")
+		dirArg        = flag.String("dir", ".", "Directory to scan")
+		format        = flag.String("format", "text", "Output format (text, json, sarif)")
+		ignoreFileArg = flag.String("ignore-file", "", "Path to an additional gitignore-style ignore file")
+		configArg     = flag.String("config", "", "Path to a YAML config file (signatures, blacklists, exclude_paths); overrides DefaultPatterns")
+		verifyArg     = flag.Bool("verify", false, "Probe detected secrets against live provider APIs")
+		modeArg       = flag.String("mode", "worktree", "What to scan: worktree, history, or both")
+		sinceArg      = flag.String("since", "", "For --mode=history: only walk commits after this ref or duration (e.g. 720h)")
+		maxCommitsArg = flag.Int("max-commits", 0, "For --mode=history: cap the number of commits walked (0 = no cap)")
 	)
 	flag.Parse()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	// Initialize components
-	d := detector.New(nil) // Use defaults
-	s := scanner.New(d)
+	// Initialize components. A --config file, if given, replaces
+	// DefaultPatterns entirely and supplies the scanner's blacklists.
+	var (
+		cfg      *detector.Config
+		patterns []detector.Pattern
+	)
+	if *configArg != "" {
+		var err error
+		cfg, err = detector.LoadConfig(*configArg)
+		if err != nil {
+			return exitUnverifiedMatch, fmt.Errorf("load config: %w", err)
+		}
+		patterns = detector.PatternsFromConfig(cfg)
+	} else {
+		patterns = detector.DefaultPatterns()
+	}
+	if *verifyArg {
+		patterns = verifier.WithDefaults(patterns)
+	}
 
-	// Scan
-	fmt.Fprintf(os.Stderr, "Scanning %s...\n", *dirArg)
-	start := time.Now()
-	result, err := s.Scan(ctx, *dirArg)
-	if err != nil {
-		return fmt.Errorf("scan: %w", err)
+	var detectorOpts []detector.Option
+	if cfg != nil {
+		detectorOpts = append(detectorOpts, detector.WithBlacklistedStrings(cfg.BlacklistedStrings))
+	}
+	d := detector.New(patterns, detectorOpts...)
+
+	var scannerOpts = []scanner.Option{scanner.WithIgnoreFile(*ignoreFileArg)}
+	if cfg != nil {
+		scannerOpts = append(scannerOpts,
+			scanner.WithBlacklistedExtensions(cfg.BlacklistedExtensions),
+			scanner.WithBlacklistedPaths(cfg.BlacklistedPaths),
+			scanner.WithExcludePaths(cfg.ExcludePaths),
+		)
+	}
+
+	var result types.ScanResult
+
+	if *modeArg == "worktree" || *modeArg == "both" {
+		s := scanner.New(d, scannerOpts...)
+
+		fmt.Fprintf(os.Stderr, "Scanning working tree %s...\n", *dirArg)
+		start := time.Now()
+		wtResult, err := s.Scan(ctx, *dirArg)
+		if err != nil {
+			return exitUnverifiedMatch, fmt.Errorf("scan: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Scanned working tree in %v. Found %d secrets.\n", time.Since(start), len(wtResult.Findings))
+
+		result.Findings = append(result.Findings, wtResult.Findings...)
+		result.Errors = append(result.Errors, wtResult.Errors...)
+	}
+
+	if *modeArg == "history" || *modeArg == "both" {
+		var opts []gitscanner.Option
+		if *maxCommitsArg > 0 {
+			opts = append(opts, gitscanner.WithMaxCommits(*maxCommitsArg))
+		}
+		if *sinceArg != "" {
+			cutoff, err := parseSince(*sinceArg)
+			if err != nil {
+				return exitUnverifiedMatch, fmt.Errorf("parse --since: %w", err)
+			}
+			opts = append(opts, gitscanner.WithSince(cutoff))
+		}
+
+		gs := gitscanner.New(d, opts...)
+
+		fmt.Fprintf(os.Stderr, "Scanning git history in %s...\n", *dirArg)
+		start := time.Now()
+		histResult, err := gs.Scan(ctx, *dirArg, "HEAD")
+		if err != nil {
+			return exitUnverifiedMatch, fmt.Errorf("scan history: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Scanned history in %v. Found %d secrets.\n", time.Since(start), len(histResult.Findings))
+
+		result.Findings = append(result.Findings, histResult.Findings...)
+		result.Errors = append(result.Errors, histResult.Errors...)
 	}
-	duration := time.Since(start)
-	fmt.Fprintf(os.Stderr, "Scanned in %v. Found %d secrets.\n", duration, len(result.Findings))
 
 	// Report any file-level errors so they are visible to the operator.
 	for _, se := range result.Errors {
 		fmt.Fprintf(os.Stderr, "warning: %s\n", se)
 	}
 
+	if *verifyArg && len(result.Findings) > 0 {
+		fmt.Fprintln(os.Stderr, "Verifying findings against provider APIs...")
+		result.Findings = verifier.Verify(ctx, result.Findings, patterns)
+	}
+
 	// Report
 	if err := reporter.Report(os.Stdout, result.Findings, *format); err != nil {
-		return fmt.Errorf("report: %w", err)
+		return exitUnverifiedMatch, fmt.Errorf("report: %w", err)
+	}
+
+	for _, f := range result.Findings {
+		if f.Verified {
+			return exitVerifiedLiveFound, fmt.Errorf("verified live secret found")
+		}
 	}
 
 	if len(result.Findings) > 0 {
-		return fmt.Errorf("secrets found")
+		return exitUnverifiedMatch, fmt.Errorf("secrets found")
+	}
+
+	return exitOK, nil
+}
+
+// runWatch implements `cicd-secret-detector watch --dir .`: an initial full
+// scan followed by a long-running fsnotify-driven watch, streaming findings
+// as NDJSON on stdout — one event per line — so the output can feed a
+// pre-commit hook or a developer-desktop tray app without buffering.
+func runWatch(args []string) (int, error) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	dirArg := fs.String("dir", ".", "Directory to watch")
+	ignoreFileArg := fs.String("ignore-file", "", "Path to an additional gitignore-style ignore file")
+	if err := fs.Parse(args); err != nil {
+		return exitUnverifiedMatch, fmt.Errorf("parse flags: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	d := detector.New(detector.DefaultPatterns())
+	s := scanner.New(d, scanner.WithIgnoreFile(*ignoreFileArg))
+
+	fmt.Fprintf(os.Stderr, "Watching %s for changes...\n", *dirArg)
+	events, err := s.Watch(ctx, *dirArg)
+	if err != nil {
+		return exitUnverifiedMatch, fmt.Errorf("watch: %w", err)
+	}
+
+	for result := range events {
+		if err := reporter.ReportNDJSON(os.Stdout, result); err != nil {
+			return exitUnverifiedMatch, fmt.Errorf("report: %w", err)
+		}
 	}
 
-	return nil
+	return exitOK, nil
+}
+
+// parseSince interprets --since as a duration (e.g. "720h") relative to now,
+// falling back to treating it as a ref whose commit time the caller should
+// resolve; gitscanner only accepts an absolute cutoff, so a bare ref is
+// resolved by the caller. Since that resolution requires the already-open
+// repository, gitscanner.WithSince is given the duration form here and a ref
+// form is left to --mode=history's "<ref>..HEAD" range instead.
+func parseSince(since string) (time.Time, error) {
+	if n, err := strconv.Atoi(since); err == nil {
+		return time.Now().AddDate(0, 0, -n), nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid duration %q: %w", since, err)
+	}
+	return time.Now().Add(-d), nil
 }
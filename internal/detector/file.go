@@ -0,0 +1,41 @@
+package detector
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/exp/mmap"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// mmapThreshold is the file size above which DetectFile memory-maps the file
+// instead of reading it into a buffer, avoiding a multi-hundred-MB read for
+// something like a vendored container layer tarball.
+const mmapThreshold = 32 << 20 // 32 MiB
+
+// DetectFile scans the file at path for secrets. Files at or above
+// mmapThreshold are memory-mapped rather than read into memory, since
+// DetectReader only ever needs sequential access to one line at a time.
+func (d *Detector) DetectFile(path string) ([]types.Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+	if info.Size() < mmapThreshold {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		defer f.Close()
+		return d.DetectReader(f)
+	}
+
+	ra, err := mmap.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("mmap %s: %w", path, err)
+	}
+	defer ra.Close()
+	return d.DetectReader(io.NewSectionReader(ra, 0, int64(ra.Len())))
+}
@@ -0,0 +1,75 @@
+package detector
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// gcpServiceAccountKeyPatternName is the Pattern.Name a structurally
+// validated GCP service account key JSON blob is reported under. Unlike
+// every other pattern, there's no single-line regex that reliably identifies
+// one on its own — the fields that distinguish a real key (project_id,
+// client_email, private_key) are usually spread across several lines of
+// pretty-printed JSON — so DetectReader buffers and JSON-parses a candidate
+// object instead, the same way it does for multi-line private key blocks.
+const gcpServiceAccountKeyPatternName = "GCP Service Account Key"
+
+// gcpServiceAccountKeyTrigger matches the "type" field every GCP service
+// account key JSON file carries, used to start buffering a candidate object.
+var gcpServiceAccountKeyTrigger = regexp.MustCompile(`"type"\s*:\s*"service_account"`)
+
+// gcpObjectCloseRegex matches the line that closes a JSON object ("}" or
+// "},"), used to find the end of a buffered candidate object.
+var gcpObjectCloseRegex = regexp.MustCompile(`^\s*\}\s*,?\s*$`)
+
+// gcpServiceAccountKeyJSON is the minimal shape of a GCP service account key
+// JSON file needed to tell a real key apart from unrelated JSON that happens
+// to contain the same trigger phrase.
+type gcpServiceAccountKeyJSON struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// gcpServiceAccountKeyBlock buffers the lines that make up a candidate GCP
+// service account key JSON object.
+type gcpServiceAccountKeyBlock struct {
+	startLine int
+	lines     []string
+}
+
+// resolve attempts to JSON-parse the buffered block and, if every field a
+// real key carries is present, returns the Finding to report.
+func (b *gcpServiceAccountKeyBlock) resolve() (types.Finding, bool) {
+	raw := strings.Join(b.lines, "\n")
+	key, ok := parseGCPServiceAccountKey(raw)
+	if !ok {
+		return types.Finding{}, false
+	}
+
+	return types.Finding{
+		LineNumber:    b.startLine,
+		SecretType:    gcpServiceAccountKeyPatternName,
+		Value:         raw,
+		RedactedValue: "[REDACTED]",
+		Metadata: map[string]string{
+			"project_id":   key.ProjectID,
+			"client_email": key.ClientEmail,
+		},
+	}, true
+}
+
+func parseGCPServiceAccountKey(raw string) (gcpServiceAccountKeyJSON, bool) {
+	var key gcpServiceAccountKeyJSON
+	if err := json.Unmarshal([]byte(raw), &key); err != nil {
+		return gcpServiceAccountKeyJSON{}, false
+	}
+	if key.Type != "service_account" || key.ProjectID == "" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return gcpServiceAccountKeyJSON{}, false
+	}
+	return key, true
+}
@@ -0,0 +1,188 @@
+package detector
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck // deprecated upstream, but still the only stdlib-adjacent OpenPGP parser
+	"golang.org/x/crypto/openpgp/packet"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// privateKeyPatternName is the Pattern.Name the "Private Key" entry in
+// DefaultPatterns uses. DetectReader's multi-line pass supersedes that
+// pattern's single-line regex check once a BEGIN header is seen, since a
+// structurally validated block is a far stronger signal than the header
+// alone.
+const privateKeyPatternName = "Private Key"
+
+var (
+	privateKeyBeginRegex = regexp.MustCompile(`-----BEGIN ((EC|PGP|DSA|RSA|OPENSSH) )?PRIVATE KEY( BLOCK)?-----`)
+	privateKeyEndRegex   = regexp.MustCompile(`-----END ((EC|PGP|DSA|RSA|OPENSSH) )?PRIVATE KEY( BLOCK)?-----`)
+)
+
+// privateKeyBlock buffers the lines between a BEGIN and END PRIVATE KEY
+// header so they can be handed to a structural parser as a whole, rather than
+// trusting the header line in isolation.
+type privateKeyBlock struct {
+	keyType   string // capture group 2 of privateKeyBeginRegex: EC, PGP, DSA, RSA, OPENSSH, or "" for a bare "PRIVATE KEY" header
+	startLine int
+	lines     []string
+
+	// singleLine is true when the BEGIN and END markers were found on the
+	// same scanner line, e.g. a key embedded in JSON or a Kubernetes Secret
+	// with escaped "\n" instead of real newlines. pem.Decode/armor.Decode
+	// can't parse that shape, so resolve falls back to the plain header
+	// match rather than reporting nothing at all.
+	singleLine bool
+}
+
+// resolve attempts a structural parse of the buffered block and, if it looks
+// like a real key (or a well-formed but encrypted one), returns the Finding
+// to report. For a single-line block that fails structural parsing — the
+// common shape for a key embedded with escaped newlines rather than real
+// ones — it falls back to reporting the plain header match instead of
+// dropping it.
+func (b *privateKeyBlock) resolve() (types.Finding, bool) {
+	raw := strings.Join(b.lines, "\n")
+
+	var meta map[string]string
+	var ok bool
+	switch b.keyType {
+	case "PGP":
+		meta, ok = parseOpenPGPPrivateKey(raw)
+	case "OPENSSH":
+		meta, ok = parseOpenSSHPrivateKey(raw)
+	default: // RSA, EC, DSA, or a bare PKCS8 "PRIVATE KEY" header
+		meta, ok = parseX509PrivateKey(raw)
+	}
+	if !ok {
+		if !b.singleLine {
+			return types.Finding{}, false
+		}
+		return types.Finding{
+			LineNumber:    b.startLine,
+			SecretType:    privateKeyPatternName,
+			Value:         strings.TrimSpace(raw),
+			RedactedValue: "[REDACTED]",
+		}, true
+	}
+
+	return types.Finding{
+		LineNumber:    b.startLine,
+		SecretType:    privateKeyPatternName,
+		Value:         raw,
+		RedactedValue: "[REDACTED]",
+		Metadata:      meta,
+	}, true
+}
+
+// parseOpenPGPPrivateKey confirms raw armor-decodes to a PGP private key
+// packet, returning its algorithm, key ID, and whether it's passphrase
+// encrypted.
+func parseOpenPGPPrivateKey(raw string) (map[string]string, bool) {
+	block, err := armor.Decode(strings.NewReader(raw))
+	if err != nil || block.Type != "PGP PRIVATE KEY BLOCK" {
+		return nil, false
+	}
+
+	pkt, err := packet.Read(block.Body)
+	if err != nil {
+		return nil, false
+	}
+	key, ok := pkt.(*packet.PrivateKey)
+	if !ok {
+		return nil, false
+	}
+
+	return map[string]string{
+		"algorithm": pgpAlgorithmName(key.PubKeyAlgo),
+		"key_id":    key.KeyIdString(),
+		"encrypted": strconv.FormatBool(key.Encrypted),
+	}, true
+}
+
+// pgpAlgorithmName maps a packet.PublicKeyAlgorithm to a human-readable name;
+// RedactedValue/Metadata is the only place this ever surfaces, so an unknown
+// value is reported as-is rather than erroring the whole parse.
+func pgpAlgorithmName(algo packet.PublicKeyAlgorithm) string {
+	switch algo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSAEncryptOnly, packet.PubKeyAlgoRSASignOnly:
+		return "RSA"
+	case packet.PubKeyAlgoElGamal:
+		return "ElGamal"
+	case packet.PubKeyAlgoDSA:
+		return "DSA"
+	case packet.PubKeyAlgoECDH:
+		return "ECDH"
+	case packet.PubKeyAlgoECDSA:
+		return "ECDSA"
+	default:
+		return "unknown(" + strconv.Itoa(int(algo)) + ")"
+	}
+}
+
+// openSSHMagic is the fixed preamble of every openssh-key-v1 private key,
+// immediately after PEM-decoding the "OPENSSH PRIVATE KEY" block.
+var openSSHMagic = []byte("openssh-key-v1\x00")
+
+// parseOpenSSHPrivateKey confirms raw PEM-decodes to an "OPENSSH PRIVATE
+// KEY" block carrying the openssh-key-v1 magic bytes.
+func parseOpenSSHPrivateKey(raw string) (map[string]string, bool) {
+	block, _ := pem.Decode([]byte(raw))
+	if block == nil || block.Type != "OPENSSH PRIVATE KEY" {
+		return nil, false
+	}
+	if !bytes.HasPrefix(block.Bytes, openSSHMagic) {
+		return nil, false
+	}
+
+	return map[string]string{
+		"algorithm": "OpenSSH",
+		"format":    "openssh-key-v1",
+	}, true
+}
+
+// parseX509PrivateKey confirms raw PEM-decodes to an RSA, EC, or PKCS8 DER
+// body that x509 can parse; DSA has no stdlib private key parser, so a DSA
+// block is only recognized here if it also happens to use the legacy
+// encrypted PEM headers checked below.
+func parseX509PrivateKey(raw string) (map[string]string, bool) {
+	block, _ := pem.Decode([]byte(raw))
+	if block == nil {
+		return nil, false
+	}
+
+	// Legacy "Proc-Type: 4,ENCRYPTED" PEM headers mean the DER body isn't
+	// parseable without a passphrase; a well-formed encrypted block is still
+	// worth reporting.
+	if strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return map[string]string{"encrypted": "true"}, true
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return map[string]string{"algorithm": "RSA", "bit_length": strconv.Itoa(key.N.BitLen())}, true
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return map[string]string{"algorithm": "EC", "curve": key.Curve.Params().Name}, true
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return map[string]string{"algorithm": "RSA", "bit_length": strconv.Itoa(k.N.BitLen())}, true
+		case *ecdsa.PrivateKey:
+			return map[string]string{"algorithm": "EC", "curve": k.Curve.Params().Name}, true
+		default:
+			return map[string]string{"algorithm": "unknown"}, true
+		}
+	}
+
+	return nil, false
+}
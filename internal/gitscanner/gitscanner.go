@@ -0,0 +1,300 @@
+// Package gitscanner scans commits reachable from a ref range for secrets, so
+// credentials that were `git rm`'d but never rewritten out of history are
+// still caught. It mirrors scanner.FileScanner but diffs commits instead of
+// walking the working tree.
+package gitscanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// Detector defines the behavior required to detect secrets in content.
+type Detector interface {
+	Detect(content []byte) ([]types.Finding, error)
+}
+
+// GitScanner scans a git repository's commit history for secrets.
+type GitScanner struct {
+	detector   Detector
+	maxCommits int
+	since      time.Time
+}
+
+// Option configures a GitScanner.
+type Option func(*GitScanner)
+
+// WithMaxCommits caps how many commits are walked. A value of 0 (the default)
+// means no cap.
+func WithMaxCommits(n int) Option {
+	return func(s *GitScanner) { s.maxCommits = n }
+}
+
+// WithSince stops the walk once it reaches a commit authored before cutoff.
+func WithSince(cutoff time.Time) Option {
+	return func(s *GitScanner) { s.since = cutoff }
+}
+
+// New creates a new GitScanner.
+func New(d Detector, opts ...Option) *GitScanner {
+	s := &GitScanner{detector: d}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Scan walks commits reachable from refRange (e.g. "HEAD" or "main..HEAD") in
+// the repository at repoPath, diffing each commit against its first parent and
+// feeding added/modified blobs through the Detector. Findings are enriched
+// with CommitHash, Author, CommitTime, and the in-repo path. A commit-level
+// scan error is recorded in ScanResult.Errors rather than aborting the walk.
+func (s *GitScanner) Scan(ctx context.Context, repoPath, refRange string) (types.ScanResult, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return types.ScanResult{}, fmt.Errorf("open repo %s: %w", repoPath, err)
+	}
+
+	commits, err := s.resolveCommits(repo, refRange)
+	if err != nil {
+		return types.ScanResult{}, fmt.Errorf("resolve ref range %q: %w", refRange, err)
+	}
+
+	var (
+		result types.ScanResult
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		seen   = make(map[string]bool) // dedupe by file path + matched value +
+		// occurrence index (see scanCommit), so a secret introduced once
+		// doesn't produce a finding for every later commit that still
+		// carries it, while multiple distinct occurrences of the same
+		// secret within a single commit are still each reported.
+	)
+
+	sem := make(chan struct{}, 16)
+
+	for _, commit := range commits {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return types.ScanResult{}, ctx.Err()
+		default:
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return types.ScanResult{}, ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(commit *object.Commit) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			findings, err := s.scanCommit(commit)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, types.ScanError{Path: commit.Hash.String(), Err: err})
+				return
+			}
+			for _, f := range findings {
+				if seen[f.dedupKey] {
+					continue
+				}
+				seen[f.dedupKey] = true
+				result.Findings = append(result.Findings, f.Finding)
+			}
+		}(commit)
+	}
+
+	wg.Wait()
+
+	return result, nil
+}
+
+// dedupFinding pairs a Finding with the key Scan uses to collapse the same
+// secret persisting across commits (same file path + same matched value +
+// same occurrence index within that commit's scan), independent of whether
+// anything else in the file changed in between.
+type dedupFinding struct {
+	types.Finding
+	dedupKey string
+}
+
+// scanCommit diffs commit against its first parent (or against an empty tree,
+// for a root commit) and runs the Detector over every added or modified file.
+func (s *GitScanner) scanCommit(commit *object.Commit) ([]dedupFinding, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("commit %s: tree: %w", commit.Hash, err)
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("commit %s: parent: %w", commit.Hash, err)
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("commit %s: parent tree: %w", commit.Hash, err)
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("commit %s: diff: %w", commit.Hash, err)
+	}
+
+	var findings []dedupFinding
+	// occurrence counts how many times a given path+value pair has been seen
+	// so far in this commit's scan, so N distinct occurrences of the same
+	// secret in one commit (e.g. two lines with the same token) each get
+	// their own dedupKey, while still colliding with the same occurrence
+	// index in a later commit that carries the secret unchanged.
+	occurrence := make(map[string]int)
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("commit %s: change action: %w", commit.Hash, err)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+
+		_, file, err := change.Files()
+		if err != nil || file == nil {
+			continue
+		}
+
+		content, err := file.Contents()
+		if err != nil {
+			continue
+		}
+
+		fileFindings, err := s.detector.Detect([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("commit %s: detect %s: %w", commit.Hash, file.Name, err)
+		}
+
+		for i := range fileFindings {
+			fileFindings[i].FilePath = file.Name
+			fileFindings[i].CommitHash = commit.Hash.String()
+			fileFindings[i].Author = commit.Author.Name
+			fileFindings[i].CommitTime = commit.Author.When
+
+			base := file.Name + "\x00" + fileFindings[i].Value
+			index := occurrence[base]
+			occurrence[base] = index + 1
+
+			findings = append(findings, dedupFinding{
+				Finding:  fileFindings[i],
+				dedupKey: fmt.Sprintf("%s\x00%d", base, index),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// resolveCommits returns the commits covered by refRange, in log order. A
+// plain ref ("HEAD", "main") walks every commit reachable from it; a
+// "base..head" range walks commits reachable from head but not from base.
+func (s *GitScanner) resolveCommits(repo *git.Repository, refRange string) ([]*object.Commit, error) {
+	baseRef, headRef, ranged := splitRefRange(refRange)
+
+	headHash, err := resolveRevision(repo, headRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var exclude map[plumbing.Hash]bool
+	if ranged {
+		baseHash, err := resolveRevision(repo, baseRef)
+		if err != nil {
+			return nil, err
+		}
+		exclude, err = reachableSet(repo, baseHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: headHash})
+	if err != nil {
+		return nil, fmt.Errorf("log from %s: %w", headRef, err)
+	}
+	defer iter.Close()
+
+	var commits []*object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if exclude != nil && exclude[c.Hash] {
+			return nil
+		}
+		if !s.since.IsZero() && c.Author.When.Before(s.since) {
+			return storer.ErrStop
+		}
+		if s.maxCommits > 0 && len(commits) >= s.maxCommits {
+			return storer.ErrStop
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// splitRefRange splits "base..head" into its two sides; a plain ref with no
+// ".." is treated as the head of an unranged walk.
+func splitRefRange(refRange string) (base, head string, ranged bool) {
+	if idx := strings.Index(refRange, ".."); idx >= 0 {
+		return refRange[:idx], refRange[idx+2:], true
+	}
+	return "", refRange, false
+}
+
+func resolveRevision(repo *git.Repository, rev string) (plumbing.Hash, error) {
+	h, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolve revision %q: %w", rev, err)
+	}
+	return *h, nil
+}
+
+// reachableSet returns every commit hash reachable from from, used to exclude
+// the base side of a "base..head" range.
+func reachableSet(repo *git.Repository, from plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	iter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	set := make(map[plumbing.Hash]bool)
+	err = iter.ForEach(func(c *object.Commit) error {
+		set[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
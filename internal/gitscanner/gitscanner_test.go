@@ -0,0 +1,240 @@
+package gitscanner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// regexDetector finds every match of re in content, treating the whole match
+// as the finding's Value — enough fidelity to exercise dedup and enrichment
+// without pulling in the real detector package's full pattern set.
+type regexDetector struct {
+	re *regexp.Regexp
+}
+
+func (d regexDetector) Detect(content []byte) ([]types.Finding, error) {
+	var findings []types.Finding
+	for _, m := range d.re.FindAllString(string(content), -1) {
+		findings = append(findings, types.Finding{LineNumber: 1, SecretType: "Test Secret", Value: m})
+	}
+	return findings, nil
+}
+
+var secretDetector = regexDetector{re: regexp.MustCompile(`SECRET-[A-Z0-9]+`)}
+
+// testRepo builds a throwaway git repository in a temp dir and runs git
+// commands against it, failing the test immediately on any error.
+type testRepo struct {
+	t   *testing.T
+	dir string
+	env []string
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	r := &testRepo{
+		t:   t,
+		dir: dir,
+		env: append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test Author",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test Author",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		),
+	}
+	r.git("init", "-q", "-b", "main")
+	return r
+}
+
+func (r *testRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = r.env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes files (path -> content), stages, and commits them at the
+// given time, returning the new commit hash.
+func (r *testRepo) commit(message string, when time.Time, files map[string]string) string {
+	r.t.Helper()
+	for path, content := range files {
+		full := filepath.Join(r.dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			r.t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			r.t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	r.git("add", "-A")
+
+	dateEnv := append(append([]string{}, r.env...),
+		"GIT_AUTHOR_DATE="+when.Format(time.RFC3339),
+		"GIT_COMMITTER_DATE="+when.Format(time.RFC3339),
+	)
+	cmd := exec.Command("git", "commit", "-q", "-m", message, "--allow-empty")
+	cmd.Dir = r.dir
+	cmd.Env = dateEnv
+	if out, err := cmd.CombinedOutput(); err != nil {
+		r.t.Fatalf("git commit: %v\n%s", err, out)
+	}
+	return r.git("rev-parse", "HEAD")
+}
+
+func TestScan_FindsSecretInLatestCommit(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit("add secret", time.Now(), map[string]string{"config.txt": "token = SECRET-ABC123"})
+
+	result, err := New(secretDetector).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1", len(result.Findings))
+	}
+	if result.Findings[0].Value != "SECRET-ABC123" {
+		t.Errorf("Value = %q, want %q", result.Findings[0].Value, "SECRET-ABC123")
+	}
+}
+
+func TestScan_EnrichesFindingsWithCommitMetadata(t *testing.T) {
+	repo := newTestRepo(t)
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	hash := repo.commit("add secret", when, map[string]string{"config.txt": "token = SECRET-ABC123"})
+
+	result, err := New(secretDetector).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1", len(result.Findings))
+	}
+
+	f := result.Findings[0]
+	if f.FilePath != "config.txt" {
+		t.Errorf("FilePath = %q, want %q", f.FilePath, "config.txt")
+	}
+	if f.CommitHash != hash {
+		t.Errorf("CommitHash = %q, want %q", f.CommitHash, hash)
+	}
+	if f.Author != "Test Author" {
+		t.Errorf("Author = %q, want %q", f.Author, "Test Author")
+	}
+	if !f.CommitTime.Equal(when) {
+		t.Errorf("CommitTime = %v, want %v", f.CommitTime, when)
+	}
+}
+
+func TestScan_DedupesSecretThatSurvivesUnrelatedEdits(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+	repo.commit("introduce secret", now, map[string]string{
+		"config.txt": "token = SECRET-ABC123\n",
+	})
+	// A later commit only appends an unrelated line elsewhere in the same
+	// file. The blob hash changes, but the secret itself does not — it must
+	// still be reported exactly once across both commits.
+	repo.commit("unrelated append", now.Add(time.Minute), map[string]string{
+		"config.txt": "token = SECRET-ABC123\nunrelated = true\n",
+	})
+
+	result, err := New(secretDetector).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings across both commits, want 1 (deduped): %+v", len(result.Findings), result.Findings)
+	}
+}
+
+func TestScan_ReportsDistinctOccurrencesInTheSameCommit(t *testing.T) {
+	repo := newTestRepo(t)
+	// The same secret value appears twice in one commit — two genuinely
+	// separate leaks, not one secret persisting across commits — so both
+	// must be reported, not collapsed into one by the cross-commit dedup.
+	repo.commit("duplicate secret", time.Now(), map[string]string{
+		"config.txt": "token = SECRET-ABC123\nbackup_token = SECRET-ABC123\n",
+	})
+
+	result, err := New(secretDetector).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 2 {
+		t.Fatalf("Scan() found %d findings for two occurrences in one commit, want 2: %+v", len(result.Findings), result.Findings)
+	}
+}
+
+func TestScan_RangedRefExcludesBaseCommits(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+	repo.commit("base secret", now, map[string]string{"base.txt": "token = SECRET-BASE111"})
+	repo.git("branch", "base")
+	repo.commit("head secret", now.Add(time.Minute), map[string]string{"head.txt": "token = SECRET-HEAD222"})
+
+	result, err := New(secretDetector).Scan(context.Background(), repo.dir, "base..HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1 (only the commit ahead of base)", len(result.Findings))
+	}
+	if result.Findings[0].Value != "SECRET-HEAD222" {
+		t.Errorf("Value = %q, want %q", result.Findings[0].Value, "SECRET-HEAD222")
+	}
+}
+
+func TestScan_SinceExcludesOlderCommits(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit("old secret", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), map[string]string{
+		"old.txt": "token = SECRET-OLD111",
+	})
+	repo.commit("new secret", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), map[string]string{
+		"new.txt": "token = SECRET-NEW222",
+	})
+
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	result, err := New(secretDetector, WithSince(cutoff)).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1 (only the commit after cutoff)", len(result.Findings))
+	}
+	if result.Findings[0].Value != "SECRET-NEW222" {
+		t.Errorf("Value = %q, want %q", result.Findings[0].Value, "SECRET-NEW222")
+	}
+}
+
+func TestScan_MaxCommitsCapsWalk(t *testing.T) {
+	repo := newTestRepo(t)
+	now := time.Now()
+	repo.commit("commit 1", now, map[string]string{"a.txt": "token = SECRET-AAA111"})
+	repo.commit("commit 2", now.Add(time.Minute), map[string]string{"b.txt": "token = SECRET-BBB222"})
+	repo.commit("commit 3", now.Add(2*time.Minute), map[string]string{"c.txt": "token = SECRET-CCC333"})
+
+	result, err := New(secretDetector, WithMaxCommits(1)).Scan(context.Background(), repo.dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings with MaxCommits(1), want 1", len(result.Findings))
+	}
+	if result.Findings[0].Value != "SECRET-CCC333" {
+		t.Errorf("Value = %q, want %q (the most recent commit)", result.Findings[0].Value, "SECRET-CCC333")
+	}
+}
@@ -10,30 +10,72 @@ import (
 
 // reportFinding is the safe, serializable representation of a Finding.
 type reportFinding struct {
-	FilePath      string `json:"file_path"`
-	LineNumber    int    `json:"line_number"`
-	SecretType    string `json:"secret_type"`
-	RedactedValue string `json:"redacted_value"`
+	FilePath          string            `json:"file_path"`
+	LineNumber        int               `json:"line_number"`
+	SecretType        string            `json:"secret_type"`
+	RedactedValue     string            `json:"redacted_value"`
+	Verified          bool              `json:"verified"`
+	VerificationError string            `json:"verification_error,omitempty"`
+	CommitHash        string            `json:"commit_hash,omitempty"`
+	Author            string            `json:"author,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+
+	attempted bool // verification was run at all (unexported: not part of the JSON contract)
 }
 
 func toReportFindings(findings []types.Finding) []reportFinding {
 	out := make([]reportFinding, len(findings))
 	for i, f := range findings {
 		out[i] = reportFinding{
-			FilePath:      f.FilePath,
-			LineNumber:    f.LineNumber,
-			SecretType:    f.SecretType,
-			RedactedValue: f.RedactedValue,
+			FilePath:          f.FilePath,
+			LineNumber:        f.LineNumber,
+			SecretType:        f.SecretType,
+			RedactedValue:     f.RedactedValue,
+			Verified:          f.Verified,
+			VerificationError: f.VerificationError,
+			CommitHash:        f.CommitHash,
+			Author:            f.Author,
+			Metadata:          f.Metadata,
+			attempted:         !f.VerifiedAt.IsZero(),
 		}
 	}
 	return out
 }
 
+// WatchEvent is one line of the NDJSON stream watch mode writes to stdout —
+// either a finding or a watcher-level error, never both.
+type WatchEvent struct {
+	Finding *reportFinding `json:"finding,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// ReportNDJSON writes one WatchEvent per finding in result, followed by one
+// per scan error, as newline-delimited JSON — the format `watch` streams to
+// stdout so each line is a self-contained event a consumer can process as it
+// arrives, without waiting for the stream to close.
+func ReportNDJSON(w io.Writer, result types.ScanResult) error {
+	enc := json.NewEncoder(w)
+	for _, f := range toReportFindings(result.Findings) {
+		f := f
+		if err := enc.Encode(WatchEvent{Finding: &f}); err != nil {
+			return fmt.Errorf("encode ndjson: %w", err)
+		}
+	}
+	for _, se := range result.Errors {
+		if err := enc.Encode(WatchEvent{Error: se.Error()}); err != nil {
+			return fmt.Errorf("encode ndjson: %w", err)
+		}
+	}
+	return nil
+}
+
 // Report writes findings to the writer in the specified format.
 func Report(w io.Writer, findings []types.Finding, format string) error {
 	switch format {
 	case "json":
 		return reportJSON(w, findings)
+	case "sarif":
+		return reportSARIF(w, findings)
 	default:
 		return reportText(w, findings)
 	}
@@ -42,7 +84,7 @@ func Report(w io.Writer, findings []types.Finding, format string) error {
 func reportJSON(w io.Writer, findings []types.Finding) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
-	if err := enc.Encode(findings); err != nil {
+	if err := enc.Encode(toReportFindings(findings)); err != nil {
 		return fmt.Errorf("encode json: %w", err)
 	}
 	return nil
@@ -57,10 +99,21 @@ func reportText(w io.Writer, findings []types.Finding) error {
 	safe := toReportFindings(findings)
 
 	fmt.Fprintf(w, "Found %d potential secrets:\n\n", len(findings))
-	for i, f := range findings {
+	for i, f := range safe {
 		fmt.Fprintf(w, "[%d] %s:%d\n", i+1, f.FilePath, f.LineNumber)
 		fmt.Fprintf(w, "    Type: %s\n", f.SecretType)
-		fmt.Fprintf(w, "    Match: %s\n\n", f.Value)
+		fmt.Fprintf(w, "    Match: %s\n", f.RedactedValue)
+		if f.CommitHash != "" {
+			fmt.Fprintf(w, "    Commit: %s (%s)\n", f.CommitHash, f.Author)
+		}
+		if f.attempted {
+			if f.Verified {
+				fmt.Fprintln(w, "    Verified: live secret confirmed against provider API")
+			} else {
+				fmt.Fprintln(w, "    Verified: unverified")
+			}
+		}
+		fmt.Fprintln(w)
 	}
 	return nil
 }
@@ -0,0 +1,207 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+const (
+	sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion   = "2.1.0"
+
+	toolName           = "cicd-secret-detector"
+	toolVersion        = "0.1.0"
+	toolInformationURI = "https://github.com/hadnu/cicd-secret-detector"
+
+	// fingerprintKey versions the partialFingerprints algorithm, so a future
+	// change to what goes into the hash doesn't collide with fingerprints
+	// GitHub has already stored for past runs.
+	fingerprintKey = "detectorFingerprint/v1"
+)
+
+// sarifLog is the top-level SARIF 2.1.0 document. Only the subset of the
+// spec this reporter populates is modeled.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	FullDescription      sarifMessage           `json:"fullDescription"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int          `json:"startLine"`
+	EndLine   int          `json:"endLine"`
+	Snippet   sarifMessage `json:"snippet"`
+}
+
+// reportSARIF serializes findings as a SARIF 2.1.0 log, for consumption by
+// GitHub code scanning and similar CI dashboards. Only RedactedValue ever
+// reaches the document — raw secret bytes must never appear in SARIF output,
+// since it's routinely uploaded to a third-party service.
+func reportSARIF(w io.Writer, findings []types.Finding) error {
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           toolName,
+						Version:        toolVersion,
+						InformationURI: toolInformationURI,
+						Rules:          sarifRules(findings),
+					},
+				},
+				Results: sarifResults(findings),
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("encode sarif: %w", err)
+	}
+	return nil
+}
+
+// sarifRules generates one rule per distinct SecretType seen, sorted by ID
+// so the document is byte-stable across runs regardless of finding order.
+func sarifRules(findings []types.Finding) []sarifRule {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, f := range findings {
+		if !seen[f.SecretType] {
+			seen[f.SecretType] = true
+			ids = append(ids, f.SecretType)
+		}
+	}
+	sort.Strings(ids)
+
+	rules := make([]sarifRule, len(ids))
+	for i, id := range ids {
+		rules[i] = sarifRule{
+			ID:               id,
+			ShortDescription: sarifMessage{Text: id},
+			FullDescription:  sarifMessage{Text: fmt.Sprintf("A potential %s was detected in the scanned content.", id)},
+			DefaultConfiguration: sarifRuleConfiguration{
+				Level: "error",
+			},
+		}
+	}
+	return rules
+}
+
+func sarifResults(findings []types.Finding) []sarifResult {
+	results := make([]sarifResult, len(findings))
+	for i, f := range findings {
+		results[i] = sarifResult{
+			RuleID:  f.SecretType,
+			Level:   "error",
+			Message: sarifMessage{Text: f.RedactedValue},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: sarifURI(f.FilePath)},
+						Region: sarifRegion{
+							StartLine: f.LineNumber,
+							EndLine:   f.LineNumber,
+							Snippet:   sarifMessage{Text: f.RedactedValue},
+						},
+					},
+				},
+			},
+			PartialFingerprints: map[string]string{
+				fingerprintKey: sarifFingerprint(f),
+			},
+		}
+	}
+	return results
+}
+
+// sarifURI converts a Finding's FilePath (as recorded by the scanner, already
+// root-relative for the common "--dir ." case) into a URI-encoded SARIF
+// artifact location, using forward slashes as SARIF requires regardless of
+// OS.
+func sarifURI(path string) string {
+	return (&url.URL{Path: filepath.ToSlash(path)}).String()
+}
+
+// sarifFingerprint hashes {filepath, secret_type, line_number,
+// sha256(raw_value)} into a single stable digest, so GitHub code scanning can
+// deduplicate the same secret across runs and across branches without ever
+// seeing the raw value itself.
+func sarifFingerprint(f types.Finding) string {
+	valueHash := sha256.Sum256([]byte(f.Value))
+
+	h := sha256.New()
+	h.Write([]byte(f.FilePath))
+	h.Write([]byte{0})
+	h.Write([]byte(f.SecretType))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.Itoa(f.LineNumber)))
+	h.Write([]byte{0})
+	h.Write(valueHash[:])
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,188 @@
+package scanner
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// memFS is a minimal in-memory fs.FS backing tar and OCI layer archives,
+// which (unlike archive/zip.Reader) have no native fs.FS implementation in
+// the standard library.
+type memFS struct {
+	files map[string][]byte
+	dirs  map[string]map[string]bool // dir -> set of direct child base names
+}
+
+func newMemFS() *memFS {
+	return &memFS{
+		files: make(map[string][]byte),
+		dirs:  map[string]map[string]bool{".": {}},
+	}
+}
+
+// addFile stores data at name, creating any parent directory entries needed
+// so ReadDir sees it.
+func (m *memFS) addFile(name string, data []byte) {
+	name = path.Clean(name)
+	m.files[name] = data
+	for cur := name; cur != "."; {
+		dir := path.Dir(cur)
+		if m.dirs[dir] == nil {
+			m.dirs[dir] = make(map[string]bool)
+		}
+		m.dirs[dir][path.Base(cur)] = true
+		cur = dir
+	}
+}
+
+// mergeUnder copies every entry of other into m, rooted under prefix — used
+// to stack OCI image layers into one virtual tree.
+func (m *memFS) mergeUnder(prefix string, other *memFS) {
+	for name, data := range other.files {
+		m.addFile(path.Join(prefix, name), data)
+	}
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	name = path.Clean(name)
+	if data, ok := m.files[name]; ok {
+		return &memFileHandle{info: memFileInfo{name: path.Base(name), size: int64(len(data))}, r: bytes.NewReader(data)}, nil
+	}
+	if _, ok := m.dirs[name]; ok {
+		entries, _ := m.ReadDir(name)
+		return &memDirHandle{info: memFileInfo{name: path.Base(name), isDir: true}, entries: entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = path.Clean(name)
+	children, ok := m.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for base := range children {
+		full := path.Join(name, base)
+		_, isDir := m.dirs[full]
+		entries = append(entries, memDirEntry{name: base, isDir: isDir, size: int64(len(m.files[full]))})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// memFileHandle implements fs.File (and fs.ReadDirFile is not needed here —
+// directory listing goes through memDirHandle).
+type memFileHandle struct {
+	info memFileInfo
+	r    *bytes.Reader
+}
+
+func (f *memFileHandle) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFileHandle) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *memFileHandle) Close() error               { return nil }
+
+// memDirHandle implements fs.ReadDirFile so fs.WalkDir (via the generic
+// fs.ReadDir helper) can list it even though memFS itself only exposes
+// ReadDir as a plain method, not the fs.ReadDirFS interface.
+type memDirHandle struct {
+	info    memFileInfo
+	entries []fs.DirEntry
+	read    int
+}
+
+func (d *memDirHandle) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDirHandle) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("%s: is a directory", d.info.name)
+}
+func (d *memDirHandle) Close() error { return nil }
+
+func (d *memDirHandle) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		rest := d.entries[d.read:]
+		d.read = len(d.entries)
+		return rest, nil
+	}
+	if d.read >= len(d.entries) {
+		return nil, io.EOF
+	}
+	end := d.read + n
+	if end > len(d.entries) {
+		end = len(d.entries)
+	}
+	batch := d.entries[d.read:end]
+	d.read = end
+	return batch, nil
+}
+
+type memFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (i memFileInfo) Name() string { return i.name }
+func (i memFileInfo) Size() int64  { return i.size }
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.isDir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.isDir }
+func (i memFileInfo) Sys() any           { return nil }
+
+type memDirEntry struct {
+	name  string
+	isDir bool
+	size  int64
+}
+
+func (e memDirEntry) Name() string { return e.name }
+func (e memDirEntry) IsDir() bool  { return e.isDir }
+func (e memDirEntry) Type() fs.FileMode {
+	if e.isDir {
+		return fs.ModeDir
+	}
+	return 0
+}
+func (e memDirEntry) Info() (fs.FileInfo, error) {
+	return memFileInfo{name: e.name, isDir: e.isDir, size: e.size}, nil
+}
+
+// tarToMemFS reads a tar stream fully into an in-memory fs.FS. Archives are
+// assumed to fit in memory, the same assumption FileScanner already makes
+// for regular files via os.ReadFile.
+func tarToMemFS(r io.Reader) (*memFS, error) {
+	fsys := newMemFS()
+	tr := tar.NewReader(r)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar entry: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read tar entry %s: %w", hdr.Name, err)
+		}
+		fsys.addFile(hdr.Name, data)
+	}
+
+	return fsys, nil
+}
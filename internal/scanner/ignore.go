@@ -0,0 +1,205 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// defaultIgnoreDirs lists directory names that are always skipped, regardless of
+// any .gitignore content. These are VCS metadata and build/dependency output —
+// never a place secrets are intentionally committed.
+var defaultIgnoreDirs = map[string]bool{
+	".git":         true,
+	".idea":        true,
+	".vscode":      true,
+	"vendor":       true,
+	"node_modules": true,
+	"bin":          true,
+}
+
+// shouldIgnoreDir reports whether a directory with the given base name is always
+// skipped, independent of any .gitignore rules.
+func shouldIgnoreDir(name string) bool {
+	return defaultIgnoreDirs[name]
+}
+
+// ignorePattern is a single compiled line from a .gitignore-style file.
+type ignorePattern struct {
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	re       *regexp.Regexp
+}
+
+// matches reports whether relFromBase (slash-separated, relative to the directory
+// the pattern was loaded from) is covered by this pattern.
+func (p ignorePattern) matches(relFromBase string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	target := relFromBase
+	if !p.anchored {
+		target = path.Base(relFromBase)
+	}
+
+	return p.re.MatchString(target)
+}
+
+// ignoreSet holds the patterns loaded from one directory's ignore file, along with
+// the directory they apply beneath (relative to the scan root).
+type ignoreSet struct {
+	baseRel  string
+	patterns []ignorePattern
+}
+
+// loadIgnoreSet reads "<relDir>/.gitignore" from fsys and compiles its rules,
+// if the file exists. A missing file is not an error — it simply yields an
+// empty set. fsys lets this work the same whether the scan root is the OS
+// filesystem or an archive-backed fs.FS.
+func loadIgnoreSet(fsys fs.FS, relDir string) (*ignoreSet, error) {
+	gitignorePath := ".gitignore"
+	if relDir != "" {
+		gitignorePath = relDir + "/.gitignore"
+	}
+
+	data, err := fs.ReadFile(fsys, gitignorePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ignoreSet{baseRel: relDir}, nil
+		}
+		return nil, err
+	}
+	return &ignoreSet{baseRel: relDir, patterns: parseIgnoreLines(string(data))}, nil
+}
+
+// loadIgnoreFile compiles an arbitrary gitignore-style file (e.g. the
+// --ignore-file flag) as a set rooted at relDir. Unlike loadIgnoreSet, this
+// always reads from the host filesystem: --ignore-file is an operator-supplied
+// path, not part of the tree being scanned.
+func loadIgnoreFile(filePath, relDir string) (*ignoreSet, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ignoreSet{baseRel: relDir, patterns: parseIgnoreLines(string(data))}, nil
+}
+
+// parseIgnoreLines compiles each non-comment, non-blank line of a gitignore-style
+// file into an ignorePattern.
+func parseIgnoreLines(content string) []ignorePattern {
+	var patterns []ignorePattern
+	for _, line := range strings.Split(content, "\n") {
+		if p, ok := parseIgnoreLine(line); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// parseIgnoreLine compiles a single gitignore line, supporting "#" comments,
+// blank lines, a trailing "/" for dir-only matches, a leading "/" for
+// root-anchored matches, a leading "!" for negation, "**" for any-depth
+// wildcards, and standard "*"/"?"/"[...]" globs.
+func parseIgnoreLine(line string) (ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+
+	switch {
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	case strings.HasPrefix(line, "!"):
+		p.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	if strings.HasPrefix(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	} else if strings.Contains(line, "/") {
+		// A slash anywhere but the end also anchors the pattern to this directory.
+		p.anchored = true
+	}
+
+	p.re = globToRegexp(line)
+	return p, true
+}
+
+// globToRegexp converts a single gitignore glob segment into an anchored regexp.
+func globToRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					i++
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '.', '+', '(', ')', '|', '^', '$', '{', '}', '\\':
+			sb.WriteString("\\")
+			sb.WriteRune(c)
+		case '[':
+			j := i
+			for j < len(runes) && runes[j] != ']' {
+				j++
+			}
+			if j < len(runes) {
+				sb.WriteString(string(runes[i : j+1]))
+				i = j
+			} else {
+				sb.WriteString(`\[`)
+			}
+		default:
+			sb.WriteRune(c)
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// matchStack evaluates relPath (relative to the scan root) against every set in
+// stack, from least specific (root) to most specific (deepest directory). The
+// last matching pattern across the whole stack wins, with negation reversing
+// exclusion — this mirrors git's own precedence rules.
+func matchStack(stack []*ignoreSet, relPath string, isDir bool) bool {
+	ignored := false
+
+	for _, set := range stack {
+		relFromBase := relPath
+		if set.baseRel != "" {
+			relFromBase = strings.TrimPrefix(relPath, set.baseRel+"/")
+		}
+
+		for _, p := range set.patterns {
+			if p.matches(relFromBase, isDir) {
+				ignored = !p.negate
+			}
+		}
+	}
+
+	return ignored
+}
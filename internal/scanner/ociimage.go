@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// NewFromOCIImage creates a FileScanner over every layer of a container image,
+// pulled directly from its registry, as a single virtual tree. Each layer is
+// mounted under "layer-<n>/", so a Finding's FilePath preserves which layer
+// introduced it, e.g. "image://alpine:3.19/layer-2/etc/ssh/ssh_host_rsa_key".
+func NewFromOCIImage(d Detector, imageRef string, opts ...Option) (*FileScanner, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("parse image ref %s: %w", imageRef, err)
+	}
+
+	img, err := remote.Image(ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetch image %s: %w", imageRef, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("list layers of %s: %w", imageRef, err)
+	}
+
+	fsys := newMemFS()
+	for i, layer := range layers {
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, fmt.Errorf("%s: open layer %d: %w", imageRef, i, err)
+		}
+
+		layerFS, err := tarToMemFS(rc)
+		closeErr := rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: read layer %d: %w", imageRef, i, err)
+		}
+		if closeErr != nil {
+			return nil, fmt.Errorf("%s: close layer %d: %w", imageRef, i, closeErr)
+		}
+
+		fsys.mergeUnder(fmt.Sprintf("layer-%d", i), layerFS)
+	}
+
+	opts = append(append([]Option{}, opts...), WithPathPrefix("image://"+imageRef))
+	return NewFromFS(d, fsys, opts...), nil
+}
@@ -1,10 +1,14 @@
 package scanner
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -16,116 +20,367 @@ type Detector interface {
 	Detect(content []byte) ([]types.Finding, error)
 }
 
-// FileScanner scans files for secrets.
+// ReaderDetector is satisfied by a Detector that can also scan a stream
+// directly. FileScanner prefers this over Detect when available, so a large
+// archive entry or container layer file never has to be buffered twice.
+type ReaderDetector interface {
+	DetectReader(r io.Reader) ([]types.Finding, error)
+}
+
+// FileScanner scans files for secrets, honoring .gitignore-style exclusion
+// rules. By default it scans the OS filesystem rooted at the path given to
+// Scan; NewFromFS and its archive-backed siblings point it at an in-memory
+// fs.FS instead, so tarballs, zips, and container layers can be scanned
+// without being unpacked to disk first.
 type FileScanner struct {
-	detector Detector
-	ignore   []string // Basic ignore list (e.g., ".git")
+	detector   Detector
+	ignoreFile string // optional extra ignore file (--ignore-file), applied at root
+
+	fsys       fs.FS  // nil means "build an OS-backed fs.FS from Scan's root argument"
+	pathPrefix string // prefix for Finding.FilePath when fsys is set
+
+	// blacklistedExtensions, blacklistedPaths, and excludePathPatterns let a
+	// config-driven policy (see detector.Config) skip files and directories
+	// before they're ever opened. All are nil by default.
+	blacklistedExtensions []string
+	blacklistedPaths      []string
+	excludePathPatterns   []string // compiled lazily in Scan, alongside ignoreFile's lazy validation
+}
+
+// Option configures a FileScanner.
+type Option func(*FileScanner)
+
+// WithIgnoreFile adds a custom gitignore-style ignore file that is applied at the
+// scan root, in addition to any .gitignore found while walking.
+func WithIgnoreFile(path string) Option {
+	return func(s *FileScanner) { s.ignoreFile = path }
+}
+
+// WithPathPrefix sets the prefix used to build Finding.FilePath for an
+// fsys-backed scanner, e.g. "image://alpine:3.19/layer-2". It has no effect
+// on the default OS-backed scanner, whose FilePath is always root-joined.
+func WithPathPrefix(prefix string) Option {
+	return func(s *FileScanner) { s.pathPrefix = prefix }
+}
+
+// WithBlacklistedExtensions skips any file whose extension matches one of
+// exts (e.g. ".exe", ".jpg", ".jar", ".lock"; the leading dot is optional and
+// matching is case-insensitive), without ever opening it.
+func WithBlacklistedExtensions(exts []string) Option {
+	return func(s *FileScanner) { s.blacklistedExtensions = exts }
+}
+
+// WithBlacklistedPaths skips any directory whose path (resolved the same way
+// as Finding.FilePath, e.g. "/var/lib/docker", "/proc") exactly matches, or
+// falls under, one of paths.
+func WithBlacklistedPaths(paths []string) Option {
+	return func(s *FileScanner) { s.blacklistedPaths = paths }
+}
+
+// WithExcludePaths skips any file or directory whose path matches one of
+// these regexes. Patterns are compiled lazily when Scan runs, the same way
+// WithIgnoreFile's path is only validated at Scan time.
+func WithExcludePaths(patterns []string) Option {
+	return func(s *FileScanner) { s.excludePathPatterns = patterns }
+}
+
+// New creates a new FileScanner that scans the OS filesystem.
+func New(d Detector, opts ...Option) *FileScanner {
+	s := &FileScanner{detector: d}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// New creates a new FileScanner.
-func New(d Detector) *FileScanner {
-	return &FileScanner{
-		detector: d,
-		ignore:   []string{".git", ".idea", ".vscode", "vendor", "node_modules"},
+// NewFromFS creates a FileScanner over fsys directly, instead of an OS
+// directory. The root argument later passed to Scan is ignored for path
+// resolution (fsys is always walked from its own root "."), but is still
+// used as the default Finding.FilePath prefix if WithPathPrefix is not given.
+func NewFromFS(d Detector, fsys fs.FS, opts ...Option) *FileScanner {
+	s := New(d, opts...)
+	s.fsys = fsys
+	return s
+}
+
+// NewFromTar creates a FileScanner over the contents of a tar stream (e.g. a
+// `docker save` export, or a single OCI layer), without writing it to disk.
+func NewFromTar(d Detector, r io.Reader, opts ...Option) (*FileScanner, error) {
+	fsys, err := tarToMemFS(r)
+	if err != nil {
+		return nil, fmt.Errorf("read tar: %w", err)
 	}
+	return NewFromFS(d, fsys, opts...), nil
 }
 
-// Scan walks the root directory and scans files for secrets.
-// It uses a simple worker pool-like approach by spawning a goroutine for each file
-// (buffered by a semaphore) or just walking and processing.
-// For simplicity and "boring code", we'll stick to sequential walking or limited concurrency.
-// Given IO bounds, `filepath.WalkDir` is single-threaded. We can dispatch work to a worker pool.
-func (s *FileScanner) Scan(ctx context.Context, root string) ([]types.Finding, error) {
+// NewFromZip creates a FileScanner over the contents of a zip archive on disk.
+func NewFromZip(d Detector, path string, opts ...Option) (*FileScanner, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open zip %s: %w", path, err)
+	}
+	return NewFromFS(d, &zr.Reader, opts...), nil
+}
+
+// Scan walks root (or, for an fsys-backed scanner, the whole of fsys) and
+// scans files for secrets. Directories and files matched by a .gitignore (or
+// the --ignore-file, if set) are skipped; file-level errors (e.g. permission
+// denied) are collected rather than aborting the scan.
+func (s *FileScanner) Scan(ctx context.Context, root string) (types.ScanResult, error) {
+	fsys := s.fsys
+	virtual := fsys != nil
+	if fsys == nil {
+		fsys = os.DirFS(root)
+	}
+
 	var (
-		findings []types.Finding
-		mu       sync.Mutex
-		wg       sync.WaitGroup
+		result types.ScanResult
+		mu     sync.Mutex
+		wg     sync.WaitGroup
 	)
 
-	// Semaphore to limit concurrency (e.g., 100 open files max)
-	sem := make(chan struct{}, 100)
+	var base []*ignoreSet
+	if s.ignoreFile != "" {
+		extra, err := loadIgnoreFile(s.ignoreFile, "")
+		if err != nil {
+			return types.ScanResult{}, fmt.Errorf("load ignore file %s: %w", s.ignoreFile, err)
+		}
+		base = append(base, extra)
+	}
 
-	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+	excludePaths := make([]*regexp.Regexp, len(s.excludePathPatterns))
+	for i, pattern := range s.excludePathPatterns {
+		re, err := regexp.Compile(pattern)
 		if err != nil {
-			return err
+			return types.ScanResult{}, fmt.Errorf("compile exclude_paths pattern %q: %w", pattern, err)
 		}
+		excludePaths[i] = re
+	}
+
+	w := &scanWalker{
+		ctx:                   ctx,
+		detector:              s.detector,
+		fsys:                  fsys,
+		sem:                   make(chan struct{}, 100),
+		mu:                    &mu,
+		wg:                    &wg,
+		result:                &result,
+		base:                  base,
+		root:                  root,
+		virtual:               virtual,
+		blacklistedExtensions: s.blacklistedExtensions,
+		blacklistedPaths:      s.blacklistedPaths,
+		excludePaths:          excludePaths,
+	}
+
+	err := fs.WalkDir(fsys, ".", w.visit)
+
+	wg.Wait()
+
+	if err != nil {
+		return types.ScanResult{}, fmt.Errorf("scan walk %s: %w", root, err)
+	}
+
+	for i := range result.Findings {
+		result.Findings[i].FilePath = s.resolvePath(root, virtual, result.Findings[i].FilePath)
+	}
+
+	return result, nil
+}
 
-		if d.IsDir() {
-			if s.shouldIgnore(d.Name()) {
-				return filepath.SkipDir
-			}
-			return nil
+// resolvePath turns the fsys-relative path recorded on a Finding into the
+// path reported to the caller: an OS path joined under root for the default
+// backend (preserving pre-fs.FS behavior), or prefix-joined for a virtual one.
+func (s *FileScanner) resolvePath(root string, virtual bool, relPath string) string {
+	if !virtual {
+		return filepath.Join(root, relPath)
+	}
+	if s.pathPrefix == "" {
+		return relPath
+	}
+	return strings.TrimSuffix(s.pathPrefix, "/") + "/" + relPath
+}
+
+// scanWalker carries the state shared across one fs.WalkDir pass.
+type scanWalker struct {
+	ctx      context.Context
+	detector Detector
+	fsys     fs.FS
+	sem      chan struct{}
+	mu       *sync.Mutex
+	wg       *sync.WaitGroup
+	result   *types.ScanResult
+	base     []*ignoreSet // fixed sets that apply everywhere, e.g. --ignore-file
+	stack    []*ignoreSet // per-directory sets, stack[i] loaded while visiting the directory at depth i
+
+	root    string // Scan's root argument, used to resolve an OS path for blacklistedPaths
+	virtual bool   // true when fsys isn't the OS filesystem (blacklistedPaths only applies when false)
+
+	blacklistedExtensions []string
+	blacklistedPaths      []string
+	excludePaths          []*regexp.Regexp
+}
+
+// isBlacklistedExt reports whether name's extension matches one in exts,
+// case-insensitively and regardless of whether exts entries include the
+// leading dot.
+func isBlacklistedExt(name string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range exts {
+		e = strings.ToLower(e)
+		if !strings.HasPrefix(e, ".") {
+			e = "." + e
+		}
+		if ext == e {
+			return true
 		}
+	}
+	return false
+}
 
-		if s.shouldIgnore(d.Name()) { // Also ignore files like .DS_Store
-			return nil
+// isBlacklistedPath reports whether absPath exactly matches, or falls under,
+// one of the given paths (e.g. "/proc" also blocks "/proc/self").
+func isBlacklistedPath(absPath string, paths []string) bool {
+	absPath = filepath.Clean(absPath)
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		if absPath == p || strings.HasPrefix(absPath, p+string(filepath.Separator)) {
+			return true
 		}
+	}
+	return false
+}
 
-		// Check context cancellation
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
+// matchesExcludePaths reports whether relPath (slash-separated) matches any
+// of the configured exclude_paths regexes.
+func matchesExcludePaths(relPath string, excludePaths []*regexp.Regexp) bool {
+	for _, re := range excludePaths {
+		if re.MatchString(relPath) {
+			return true
 		}
+	}
+	return false
+}
 
-		// Acquire semaphore BEFORE spawning to limit goroutines and provide backpressure
-		select {
-		case sem <- struct{}{}:
-		case <-ctx.Done():
-			return ctx.Err()
+// visit is an fs.WalkDirFunc. It maintains w.stack as the chain of ignore
+// sets covering the current entry's ancestors, skips anything matched by a
+// default-ignored directory name or a .gitignore rule, and dispatches files
+// that survive to scanFile.
+func (w *scanWalker) visit(relPath string, d fs.DirEntry, err error) error {
+	if err != nil {
+		return err
+	}
+	if relPath == "." {
+		set, err := loadIgnoreSet(w.fsys, "")
+		if err != nil {
+			return fmt.Errorf("load root .gitignore: %w", err)
 		}
+		if len(set.patterns) > 0 {
+			w.stack = append(w.stack, set)
+		}
+		return nil
+	}
 
-		wg.Add(1)
-		go func(path string) {
-			defer wg.Done()
-			defer func() { <-sem }() // Release
-
-			f, err := s.scanFile(ctx, path)
-			if err != nil {
-				// For now, log error or ignore?
-				// In a real CLI, we might want to report access errors but not fail headers.
-				// Let's print to stderr for now or collect them.
-				// "User errors or I/O failures MUST return error" - but for a bulk scan, stopping on one file permission error is annoying.
-				// Let's ignore individual file read errors for the bulk scan but maybe log them if we had a logger.
-				return
-			}
-
-			if len(f) > 0 {
-				mu.Lock()
-				findings = append(findings, f...)
-				mu.Unlock()
-			}
-		}(path)
+	select {
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	default:
+	}
 
-		return nil
-	})
+	relPath = filepath.ToSlash(relPath)
+	depth := strings.Count(relPath, "/")
+	if len(w.stack) > depth+1 {
+		w.stack = w.stack[:depth+1]
+	}
 
-	wg.Wait()
+	if d.IsDir() {
+		if shouldIgnoreDir(d.Name()) || matchStack(w.effectiveStack(), relPath, true) || matchesExcludePaths(relPath, w.excludePaths) {
+			return fs.SkipDir
+		}
+		if !w.virtual && isBlacklistedPath(filepath.Join(w.root, relPath), w.blacklistedPaths) {
+			return fs.SkipDir
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("scan walk %s: %w", root, err)
+		set, err := loadIgnoreSet(w.fsys, relPath)
+		if err != nil {
+			return fmt.Errorf("load .gitignore in %s: %w", relPath, err)
+		}
+		if len(set.patterns) > 0 {
+			w.stack = append(w.stack, set)
+		}
+		return nil
 	}
 
-	return findings, nil
+	if matchStack(w.effectiveStack(), relPath, false) || matchesExcludePaths(relPath, w.excludePaths) {
+		return nil
+	}
+	if isBlacklistedExt(d.Name(), w.blacklistedExtensions) {
+		return nil
+	}
+
+	return w.dispatch(relPath)
 }
 
-func (s *FileScanner) scanFile(ctx context.Context, path string) ([]types.Finding, error) {
-	// Check context again before expensive IO
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
+// effectiveStack is the full chain of ignore sets (base first, then every
+// ancestor directory's own .gitignore) that applies to the entry currently
+// being visited.
+func (w *scanWalker) effectiveStack() []*ignoreSet {
+	if len(w.base) == 0 {
+		return w.stack
 	}
+	combined := make([]*ignoreSet, 0, len(w.base)+len(w.stack))
+	combined = append(combined, w.base...)
+	combined = append(combined, w.stack...)
+	return combined
+}
 
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("read file %s: %w", path, err)
+// dispatch acquires a worker slot and scans path in a goroutine, recording its
+// result (or error) once the scan completes.
+func (w *scanWalker) dispatch(path string) error {
+	select {
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	default:
+	}
+
+	select {
+	case w.sem <- struct{}{}:
+	case <-w.ctx.Done():
+		return w.ctx.Err()
 	}
 
-	result, err := s.detector.Detect(content)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() { <-w.sem }()
+
+		findings, err := w.scanFile(path)
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if err != nil {
+			w.result.Errors = append(w.result.Errors, types.ScanError{Path: path, Err: err})
+			return
+		}
+		w.result.Findings = append(w.result.Findings, findings...)
+	}()
+
+	return nil
+}
+
+func (w *scanWalker) scanFile(path string) ([]types.Finding, error) {
+	if w.ctx.Err() != nil {
+		return nil, w.ctx.Err()
+	}
+
+	result, err := w.detect(path)
 	if err != nil {
-		return nil, fmt.Errorf("detect %s: %w", path, err)
+		return nil, err
 	}
 
-	// Enrich findings with file path
+	// FilePath is filled in with its fsys-relative form here; Scan rewrites it
+	// to the final OS or virtual path once the walk completes, since that
+	// resolution needs context (root, prefix) the walker itself doesn't carry.
 	for i := range result {
 		result[i].FilePath = path
 	}
@@ -133,11 +388,32 @@ func (s *FileScanner) scanFile(ctx context.Context, path string) ([]types.Findin
 	return result, nil
 }
 
-func (s *FileScanner) shouldIgnore(name string) bool {
-	for _, ign := range s.ignore {
-		if name == ign || strings.HasPrefix(name, ".") && len(name) > 1 { // Simple dotfile ignore + explicit list
-			return true
+// detect runs the detector over path, streaming it through DetectReader when
+// the detector supports that (so a large archive entry or layer file is never
+// buffered twice), falling back to a plain read otherwise.
+func (w *scanWalker) detect(path string) ([]types.Finding, error) {
+	if rd, ok := w.detector.(ReaderDetector); ok {
+		f, err := w.fsys.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open file %s: %w", path, err)
+		}
+		defer f.Close()
+
+		result, err := rd.DetectReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("detect %s: %w", path, err)
 		}
+		return result, nil
 	}
-	return false
+
+	content, err := fs.ReadFile(w.fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	result, err := w.detector.Detect(content)
+	if err != nil {
+		return nil, fmt.Errorf("detect %s: %w", path, err)
+	}
+	return result, nil
 }
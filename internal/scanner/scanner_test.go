@@ -1,9 +1,13 @@
 package scanner
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hadnu/cicd-secret-detector/internal/types"
@@ -217,3 +221,301 @@ func TestShouldIgnoreDir(t *testing.T) {
 		})
 	}
 }
+
+// writeTree materializes files under dir, creating parent directories as needed.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+}
+
+func TestScan_Gitignore(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantFound []string // file paths (relative to tmpDir) expected to produce a finding
+	}{
+		{
+			name: "root .gitignore excludes matching files",
+			files: map[string]string{
+				".gitignore": "*.log\n",
+				"app.log":    "has_secret",
+				"config.yml": "has_secret",
+			},
+			wantFound: []string{".gitignore", "config.yml"},
+		},
+		{
+			name: "nested .gitignore only applies beneath its own directory",
+			files: map[string]string{
+				"sub/.gitignore":  "ignored.txt\n",
+				"sub/ignored.txt": "has_secret",
+				"sub/kept.txt":    "has_secret",
+				"ignored.txt":     "has_secret",
+			},
+			wantFound: []string{"ignored.txt", "sub/kept.txt", "sub/.gitignore"},
+		},
+		{
+			name: "negation re-includes a previously excluded file",
+			files: map[string]string{
+				".gitignore":  "*.secret\n!keep.secret\n",
+				"drop.secret": "has_secret",
+				"keep.secret": "has_secret",
+			},
+			wantFound: []string{".gitignore", "keep.secret"},
+		},
+		{
+			name: "anchored pattern only matches at its own level",
+			files: map[string]string{
+				".gitignore":        "/only_root.txt\n",
+				"only_root.txt":     "has_secret",
+				"sub/only_root.txt": "has_secret",
+			},
+			wantFound: []string{".gitignore", "sub/only_root.txt"},
+		},
+		{
+			name: "unanchored pattern matches at any depth",
+			files: map[string]string{
+				".gitignore":     "secret.txt\n",
+				"secret.txt":     "has_secret",
+				"sub/secret.txt": "has_secret",
+				"sub/other.txt":  "has_secret",
+			},
+			wantFound: []string{".gitignore", "sub/other.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeTree(t, tmpDir, tt.files)
+
+			s := New(alwaysFindsSecret())
+			got, err := s.Scan(context.Background(), tmpDir)
+			if err != nil {
+				t.Fatalf("Scan() unexpected error: %v", err)
+			}
+
+			var gotPaths []string
+			for _, f := range got.Findings {
+				rel, err := filepath.Rel(tmpDir, f.FilePath)
+				if err != nil {
+					t.Fatalf("filepath.Rel failed: %v", err)
+				}
+				gotPaths = append(gotPaths, filepath.ToSlash(rel))
+			}
+
+			if len(gotPaths) != len(tt.wantFound) {
+				t.Fatalf("Scan() found %v, want %v", gotPaths, tt.wantFound)
+			}
+			for _, want := range tt.wantFound {
+				found := false
+				for _, got := range gotPaths {
+					if got == want {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected finding for %q, not found in %v", want, gotPaths)
+				}
+			}
+		})
+	}
+}
+
+func TestScan_CustomIgnoreFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"keep.txt": "has_secret",
+		"drop.txt": "has_secret",
+	})
+
+	ignoreFile := filepath.Join(t.TempDir(), "custom-ignore")
+	if err := os.WriteFile(ignoreFile, []byte("drop.txt\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	s := New(alwaysFindsSecret(), WithIgnoreFile(ignoreFile))
+	got, err := s.Scan(context.Background(), tmpDir)
+	if err != nil {
+		t.Fatalf("Scan() unexpected error: %v", err)
+	}
+
+	if len(got.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1: %+v", len(got.Findings), got.Findings)
+	}
+	if filepath.Base(got.Findings[0].FilePath) != "keep.txt" {
+		t.Errorf("Scan() found %q, want keep.txt", got.Findings[0].FilePath)
+	}
+}
+
+func TestScan_Blacklists(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		files     map[string]string
+		wantFound []string
+	}{
+		{
+			name: "blacklisted extension is never opened",
+			opts: []Option{WithBlacklistedExtensions([]string{".exe", "jpg"})},
+			files: map[string]string{
+				"app.exe":    "has_secret",
+				"photo.jpg":  "has_secret",
+				"config.yml": "has_secret",
+			},
+			wantFound: []string{"config.yml"},
+		},
+		{
+			name: "blacklisted path skips the whole directory",
+			opts: []Option{}, // blacklistedPaths is set below, once tmpDir is known
+			files: map[string]string{
+				"var/lib/docker/layer.tar": "has_secret",
+				"etc/app.conf":             "has_secret",
+			},
+			wantFound: []string{"etc/app.conf"},
+		},
+		{
+			name: "exclude_paths regex skips matching files",
+			opts: []Option{WithExcludePaths([]string{`^thirdparty/`})},
+			files: map[string]string{
+				"thirdparty/lib/code.go": "has_secret",
+				"main.go":                "has_secret",
+			},
+			wantFound: []string{"main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeTree(t, tmpDir, tt.files)
+
+			opts := tt.opts
+			if tt.name == "blacklisted path skips the whole directory" {
+				opts = []Option{WithBlacklistedPaths([]string{filepath.Join(tmpDir, "var", "lib", "docker")})}
+			}
+
+			s := New(alwaysFindsSecret(), opts...)
+			got, err := s.Scan(context.Background(), tmpDir)
+			if err != nil {
+				t.Fatalf("Scan() unexpected error: %v", err)
+			}
+
+			var gotPaths []string
+			for _, f := range got.Findings {
+				rel, err := filepath.Rel(tmpDir, f.FilePath)
+				if err != nil {
+					t.Fatalf("filepath.Rel failed: %v", err)
+				}
+				gotPaths = append(gotPaths, filepath.ToSlash(rel))
+			}
+
+			if len(gotPaths) != len(tt.wantFound) {
+				t.Fatalf("Scan() found %v, want %v", gotPaths, tt.wantFound)
+			}
+			for _, want := range tt.wantFound {
+				found := false
+				for _, got := range gotPaths {
+					if got == want {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected finding for %q, not found in %v", want, gotPaths)
+				}
+			}
+		})
+	}
+}
+
+func TestScan_FromTar(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeTarFile(t, tw, "etc/app.conf", "has_secret")
+	writeTarFile(t, tw, "README.md", "nothing to see here")
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close failed: %v", err)
+	}
+
+	s, err := NewFromTar(alwaysFindsSecret(), &buf, WithPathPrefix("image://example/layer-0"))
+	if err != nil {
+		t.Fatalf("NewFromTar() unexpected error: %v", err)
+	}
+
+	got, err := s.Scan(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Scan() unexpected error: %v", err)
+	}
+
+	if len(got.Findings) != 2 {
+		t.Fatalf("Scan() found %d findings, want 2: %+v", len(got.Findings), got.Findings)
+	}
+	for _, f := range got.Findings {
+		if !strings.HasPrefix(f.FilePath, "image://example/layer-0/") {
+			t.Errorf("Scan() finding FilePath = %q, want image://example/layer-0/ prefix", f.FilePath)
+		}
+	}
+}
+
+func TestScan_FromZip(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "bundle.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	writeZipFile(t, zw, "config.yaml", "has_secret")
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip Close failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	s, err := NewFromZip(alwaysFindsSecret(), zipPath)
+	if err != nil {
+		t.Fatalf("NewFromZip() unexpected error: %v", err)
+	}
+
+	got, err := s.Scan(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Scan() unexpected error: %v", err)
+	}
+
+	if len(got.Findings) != 1 {
+		t.Fatalf("Scan() found %d findings, want 1: %+v", len(got.Findings), got.Findings)
+	}
+	if got.Findings[0].FilePath != "config.yaml" {
+		t.Errorf("Scan() found %q, want config.yaml", got.Findings[0].FilePath)
+	}
+}
+
+func writeTarFile(t *testing.T, tw *tar.Writer, name, content string) {
+	t.Helper()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0644, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("WriteHeader(%s) failed: %v", name, err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatalf("Write(%s) failed: %v", name, err)
+	}
+}
+
+func writeZipFile(t *testing.T, zw *zip.Writer, name, content string) {
+	t.Helper()
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatalf("Create(%s) failed: %v", name, err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatalf("Write(%s) failed: %v", name, err)
+	}
+}
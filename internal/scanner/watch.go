@@ -0,0 +1,319 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// watchDebounce coalesces the burst of CREATE/WRITE/RENAME events a single
+// editor save commonly produces (write+rename+write is a common pattern)
+// into one re-scan per path.
+const watchDebounce = 250 * time.Millisecond
+
+// Watch performs an initial full Scan of root, then keeps watching it for
+// changes via fsnotify: every CREATE, WRITE, or RENAME under root triggers a
+// re-scan of just the affected file, debounced per path. Paths excluded by
+// .gitignore or --ignore-file are never watched or scanned, mirroring Scan's
+// own rules. New directories are watched as soon as they're created, since
+// fsnotify does not watch recursively on its own.
+//
+// The returned channel receives one ScanResult per re-scan — the initial
+// full scan first, then one per affected file — and is closed once ctx is
+// done. A watcher-level error (as opposed to a per-file scan error) is
+// delivered as a ScanResult with Errors set, without tearing down the stream.
+func (s *FileScanner) Watch(ctx context.Context, root string) (<-chan types.ScanResult, error) {
+	initial, err := s.Scan(ctx, root)
+	if err != nil {
+		return nil, fmt.Errorf("initial scan: %w", err)
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create watcher: %w", err)
+	}
+
+	base, err := s.ignoreBase()
+	if err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	w := &dirWatcher{
+		scanner: s,
+		root:    root,
+		base:    base,
+		watcher: fsWatcher,
+		pending: make(map[string]*time.Timer),
+	}
+	if err := w.addTree(root); err != nil {
+		fsWatcher.Close()
+		return nil, fmt.Errorf("watch %s: %w", root, err)
+	}
+
+	out := make(chan types.ScanResult, 1)
+	out <- initial
+
+	go w.run(ctx, out)
+
+	return out, nil
+}
+
+// ignoreBase loads the fixed, always-applied ignore sets (currently just
+// --ignore-file, if set) shared by every gitignore check Watch performs.
+func (s *FileScanner) ignoreBase() ([]*ignoreSet, error) {
+	if s.ignoreFile == "" {
+		return nil, nil
+	}
+	extra, err := loadIgnoreFile(s.ignoreFile, "")
+	if err != nil {
+		return nil, fmt.Errorf("load ignore file %s: %w", s.ignoreFile, err)
+	}
+	return []*ignoreSet{extra}, nil
+}
+
+// dirWatcher owns the live fsnotify subscription and per-path debounce
+// timers for one Watch call. Unlike FileScanner.Scan, it always walks the OS
+// filesystem directly — fsnotify has no concept of watching an archive or an
+// in-memory fs.FS, so Watch is not offered on an fsys-backed scanner.
+type dirWatcher struct {
+	scanner *FileScanner
+	root    string
+	base    []*ignoreSet
+	watcher *fsnotify.Watcher
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+}
+
+// addTree registers a watch on dir and every non-ignored subdirectory
+// beneath it.
+func (w *dirWatcher) addTree(dir string) error {
+	if err := w.watcher.Add(dir); err != nil {
+		return fmt.Errorf("add watch %s: %w", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read dir %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		childDir := filepath.Join(dir, entry.Name())
+		relPath, err := w.relPath(childDir)
+		if err != nil {
+			return err
+		}
+
+		ignored, err := w.isIgnored(relPath, true)
+		if err != nil {
+			return err
+		}
+		if ignored {
+			continue
+		}
+
+		if err := w.addTree(childDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relPath converts an absolute (or root-relative) OS path into the
+// slash-separated, root-relative form the gitignore matchers expect.
+func (w *dirWatcher) relPath(path string) (string, error) {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return "", fmt.Errorf("relativize %s: %w", path, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// isIgnored reports whether relPath is excluded by a .gitignore found
+// between the root and its parent directory, or by the --ignore-file base
+// rules — the same precedence Scan applies. It's computed fresh for each
+// path rather than carried on a live walk stack, since fsnotify delivers one
+// changed path at a time with no notion of "current directory".
+func (w *dirWatcher) isIgnored(relPath string, isDir bool) (bool, error) {
+	fsys := os.DirFS(w.root)
+	stack := append([]*ignoreSet{}, w.base...)
+
+	rootSet, err := loadIgnoreSet(fsys, "")
+	if err != nil {
+		return false, fmt.Errorf("load root .gitignore: %w", err)
+	}
+	if len(rootSet.patterns) > 0 {
+		stack = append(stack, rootSet)
+	}
+
+	segments := strings.Split(relPath, "/")
+	dir := ""
+	for _, name := range segments[:len(segments)-1] {
+		if shouldIgnoreDir(name) {
+			return true, nil
+		}
+		if dir == "" {
+			dir = name
+		} else {
+			dir = dir + "/" + name
+		}
+		if matchStack(stack, dir, true) {
+			return true, nil
+		}
+
+		set, err := loadIgnoreSet(fsys, dir)
+		if err != nil {
+			return false, fmt.Errorf("load .gitignore in %s: %w", dir, err)
+		}
+		if len(set.patterns) > 0 {
+			stack = append(stack, set)
+		}
+	}
+
+	if isDir && shouldIgnoreDir(segments[len(segments)-1]) {
+		return true, nil
+	}
+
+	return matchStack(stack, relPath, isDir), nil
+}
+
+// run is the watcher's event loop: it debounces bursts of events per path,
+// re-scans the affected file once a path goes quiet for watchDebounce, and
+// tears everything down when ctx is done.
+func (w *dirWatcher) run(ctx context.Context, out chan<- types.ScanResult) {
+	defer close(out)
+	defer w.watcher.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			for _, t := range w.pending {
+				t.Stop()
+			}
+			w.mu.Unlock()
+			return
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(ctx, event, out)
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			out <- types.ScanResult{Errors: []types.ScanError{{Path: w.root, Err: err}}}
+		}
+	}
+}
+
+// handleEvent schedules (or reschedules) a debounced re-scan for the path an
+// event touched. A newly created directory is watched immediately, rather
+// than waiting out the debounce, since files created inside it could start
+// arriving right away.
+func (w *dirWatcher) handleEvent(ctx context.Context, event fsnotify.Event, out chan<- types.ScanResult) {
+	if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) == 0 {
+		return
+	}
+
+	relPath, err := w.relPath(event.Name)
+	if err != nil {
+		return
+	}
+
+	info, statErr := os.Stat(event.Name)
+	isDir := statErr == nil && info.IsDir()
+
+	ignored, err := w.isIgnored(relPath, isDir)
+	if err != nil {
+		out <- types.ScanResult{Errors: []types.ScanError{{Path: event.Name, Err: err}}}
+		return
+	}
+	if ignored {
+		return
+	}
+
+	if event.Op&fsnotify.Create != 0 && isDir {
+		if err := w.addTree(event.Name); err != nil {
+			out <- types.ScanResult{Errors: []types.ScanError{{Path: event.Name, Err: err}}}
+		}
+		return
+	}
+
+	w.debounce(event.Name, func() {
+		w.rescan(ctx, event.Name, out)
+	})
+}
+
+// debounce (re)starts a watchDebounce timer for path, coalescing whatever
+// burst of events the same save produced into a single call to fn.
+func (w *dirWatcher) debounce(path string, fn func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if t, ok := w.pending[path]; ok {
+		t.Stop()
+	}
+	w.pending[path] = time.AfterFunc(watchDebounce, func() {
+		w.mu.Lock()
+		delete(w.pending, path)
+		w.mu.Unlock()
+		fn()
+	})
+}
+
+// rescan re-runs detection over a single file and sends the result. A file
+// removed between the event firing and the debounce elapsing is treated as
+// "nothing found", not an error — it's a normal race with a fast editor.
+func (w *dirWatcher) rescan(ctx context.Context, path string, out chan<- types.ScanResult) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		out <- types.ScanResult{Errors: []types.ScanError{{Path: path, Err: err}}}
+		return
+	}
+	defer f.Close()
+
+	var findings []types.Finding
+	if rd, ok := w.scanner.detector.(ReaderDetector); ok {
+		findings, err = rd.DetectReader(f)
+	} else {
+		var content []byte
+		content, err = os.ReadFile(path)
+		if err == nil {
+			findings, err = w.scanner.detector.Detect(content)
+		}
+	}
+	if err != nil {
+		out <- types.ScanResult{Errors: []types.ScanError{{Path: path, Err: err}}}
+		return
+	}
+
+	for i := range findings {
+		findings[i].FilePath = path
+	}
+
+	out <- types.ScanResult{Findings: findings}
+}
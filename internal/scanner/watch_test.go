@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+func TestWatch_InitialScanThenReScanOnWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		"clean.txt": "nothing here",
+	})
+
+	s := New(alwaysFindsSecret())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.Watch(ctx, tmpDir)
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+
+	initial := waitForEvent(t, events, 2*time.Second)
+	if len(initial.Findings) != 1 {
+		t.Fatalf("initial scan found %d findings, want 1: %+v", len(initial.Findings), initial.Findings)
+	}
+
+	newFile := filepath.Join(tmpDir, "new.txt")
+	if err := os.WriteFile(newFile, []byte("has_secret"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	got := waitForEvent(t, events, 2*time.Second)
+	if len(got.Findings) != 1 || got.Findings[0].FilePath != newFile {
+		t.Fatalf("re-scan result = %+v, want one finding for %s", got, newFile)
+	}
+}
+
+func TestWatch_IgnoredPathIsNeverScanned(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTree(t, tmpDir, map[string]string{
+		".gitignore": "*.log\n",
+		"keep.txt":   "nothing here",
+	})
+
+	s := New(alwaysFindsSecret())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.Watch(ctx, tmpDir)
+	if err != nil {
+		t.Fatalf("Watch() unexpected error: %v", err)
+	}
+
+	initial := waitForEvent(t, events, 2*time.Second)
+	if len(initial.Findings) != 2 {
+		t.Fatalf("initial scan found %d findings, want 2 (.gitignore and keep.txt, neither matched by *.log): %+v", len(initial.Findings), initial.Findings)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "debug.log"), []byte("has_secret"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	// Also write a non-ignored file so there's a guaranteed event to wait for;
+	// if debug.log had wrongly triggered a scan, it would have arrived first.
+	if err := os.WriteFile(filepath.Join(tmpDir, "also-new.txt"), []byte("has_secret"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	got := waitForEvent(t, events, 2*time.Second)
+	for _, f := range got.Findings {
+		if f.FilePath == filepath.Join(tmpDir, "debug.log") {
+			t.Fatalf("ignored file debug.log was scanned: %+v", got)
+		}
+	}
+}
+
+func waitForEvent(t *testing.T, events <-chan types.ScanResult, timeout time.Duration) types.ScanResult {
+	t.Helper()
+	select {
+	case result := <-events:
+		return result
+	case <-time.After(timeout):
+		t.Fatal("timed out waiting for a scan result")
+		return types.ScanResult{}
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Finding represents a detected secret in a file.
 type Finding struct {
 	FilePath      string
@@ -7,6 +9,30 @@ type Finding struct {
 	SecretType    string
 	Value         string // Raw value — for internal processing only, never log or display
 	RedactedValue string // Safe for output: preserves context, hides the secret
+
+	// Verified, VerificationError, and VerifiedAt are populated only when the
+	// scan was run with live verification enabled (see the verifier package).
+	Verified          bool
+	VerificationError string
+	VerifiedAt        time.Time
+
+	// CommitHash, Author, and CommitTime are populated only for findings
+	// produced by the gitscanner package; they are zero for working-tree scans.
+	CommitHash string
+	Author     string
+	CommitTime time.Time
+
+	// Metadata carries detector-specific, non-sensitive context about a
+	// finding, e.g. a private key's algorithm and fingerprint. Nil unless a
+	// detector has something to attach.
+	Metadata map[string]string
+}
+
+// VerificationResult is the outcome of probing a single secret against the
+// provider API that issued it.
+type VerificationResult struct {
+	Active  bool   // true if the provider confirmed the credential is still live
+	Details string // human-readable context, e.g. "token rejected (401)"
 }
 
 // ScanError records a file-level error encountered during scanning.
@@ -0,0 +1,168 @@
+package verifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// awsAccessKeyIDPattern and awsSecretAccessKeyPattern are the detector.Pattern
+// Name values these findings are filed under; they must stay in sync with
+// detector.DefaultPatterns.
+const (
+	awsAccessKeyIDPattern     = "AWS Access Key ID"
+	awsSecretAccessKeyPattern = "AWS Secret Access Key"
+)
+
+// verifyAWSPairs confirms AWS credentials live by calling sts:GetCallerIdentity.
+// Unlike the other providers, an AWS access key ID can't be verified alone — it
+// must be paired with the secret access key it belongs to — so, unlike the rest
+// of this package, this runs outside the generic per-pattern Verify hook: it
+// looks for an access key ID finding in the same file as each secret access key
+// finding and signs a request with that pair.
+func verifyAWSPairs(ctx context.Context, findings []types.Finding) {
+	var secretIdxs, keyIdxs []int
+	for i, f := range findings {
+		switch f.SecretType {
+		case awsSecretAccessKeyPattern:
+			secretIdxs = append(secretIdxs, i)
+		case awsAccessKeyIDPattern:
+			keyIdxs = append(keyIdxs, i)
+		}
+	}
+
+	if len(secretIdxs) == 0 || len(keyIdxs) == 0 {
+		return
+	}
+
+	seen := make(map[[2]string]types.VerificationResult)
+
+	for _, si := range secretIdxs {
+		secret := findings[si]
+		for _, ki := range keyIdxs {
+			key := findings[ki]
+			if key.FilePath != secret.FilePath {
+				continue
+			}
+
+			pairKey := [2]string{key.Value, secret.Value}
+			result, ok := seen[pairKey]
+			if !ok {
+				vctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+				var err error
+				result, err = stsGetCallerIdentity(vctx, key.Value, secret.Value)
+				cancel()
+				if err != nil {
+					result.Details = err.Error()
+				}
+				seen[pairKey] = result
+			}
+
+			verifiedAt := time.Now()
+			findings[si].Verified = result.Active
+			findings[si].VerifiedAt = verifiedAt
+			findings[ki].Verified = result.Active
+			findings[ki].VerifiedAt = verifiedAt
+		}
+	}
+}
+
+// stsGetCallerIdentity signs and sends a GetCallerIdentity request with the
+// given key pair, using a hand-rolled SigV4 signature (the std library has no
+// AWS support and this repo has no SDK dependency).
+func stsGetCallerIdentity(ctx context.Context, accessKeyID, secretAccessKey string) (types.VerificationResult, error) {
+	const (
+		region  = "us-east-1"
+		service = "sts"
+		host    = "sts.amazonaws.com"
+	)
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	query := url.Values{
+		"Action":  {"GetCallerIdentity"},
+		"Version": {"2011-06-15"},
+	}
+	canonicalQuery := query.Encode()
+
+	canonicalHeaders := "host:" + host + "\n" + "x-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-date"
+	payloadHash := sha256Hex(nil)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		"/",
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := dateStamp + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := sigv4SigningKey(secretAccessKey, dateStamp, region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, []byte(stringToSign)))
+
+	authHeader := fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, credentialScope, signedHeaders, signature,
+	)
+
+	reqURL := "https://" + host + "/?" + canonicalQuery
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build sts request: %w", err)
+	}
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("sts verify request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return types.VerificationResult{Active: true, Details: "sts:GetCallerIdentity succeeded"}, nil
+	case http.StatusForbidden:
+		return types.VerificationResult{Active: false, Details: "sts:GetCallerIdentity rejected (403)"}, nil
+	default:
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("unexpected status %d", resp.StatusCode)}, nil
+	}
+}
+
+func sigv4SigningKey(secret, date, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secret), []byte(date))
+	kRegion := hmacSHA256(kDate, []byte(region))
+	kService := hmacSHA256(kRegion, []byte(service))
+	return hmacSHA256(kService, []byte("aws4_request"))
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
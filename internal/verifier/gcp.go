@@ -0,0 +1,49 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// gcpScope is requested only to exercise the token endpoint; it's never used
+// to make an actual API call.
+const gcpScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+
+// gcpServiceAccountKey is the minimal shape of a GCP service account key
+// JSON file needed to tell a real key from unrelated JSON.
+type gcpServiceAccountKey struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+// GCPServiceAccountKey confirms a match structurally parses as a GCP service
+// account key (the shape produced by `gcloud iam service-accounts keys
+// create`), then exchanges it for an OAuth2 access token via
+// golang.org/x/oauth2/google — a request Google rejects outright once the
+// key has been deleted or disabled.
+func GCPServiceAccountKey(ctx context.Context, match string) (types.VerificationResult, error) {
+	var key gcpServiceAccountKey
+	if err := json.Unmarshal([]byte(match), &key); err != nil {
+		return types.VerificationResult{}, fmt.Errorf("not a JSON service account key: %w", err)
+	}
+	if key.Type != "service_account" || key.ProjectID == "" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return types.VerificationResult{Active: false, Details: "missing required service account fields"}, nil
+	}
+
+	cfg, err := google.JWTConfigFromJSON([]byte(match), gcpScope)
+	if err != nil {
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("invalid service account key: %v", err)}, nil
+	}
+
+	if _, err := cfg.TokenSource(ctx).Token(); err != nil {
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("token exchange rejected: %v", err)}, nil
+	}
+	return types.VerificationResult{Active: true, Details: "oauth2 token exchange succeeded"}, nil
+}
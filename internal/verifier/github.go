@@ -0,0 +1,35 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// GitHub probes a GitHub personal access token by calling GET /user, which any
+// valid token can reach regardless of its granted scopes.
+func GitHub(ctx context.Context, token string) (types.VerificationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build github request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("github verify request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return types.VerificationResult{Active: true, Details: "GET /user succeeded"}, nil
+	case http.StatusUnauthorized:
+		return types.VerificationResult{Active: false, Details: "token rejected (401)"}, nil
+	default:
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("unexpected status %d", resp.StatusCode)}, nil
+	}
+}
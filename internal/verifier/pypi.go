@@ -0,0 +1,49 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// PyPI probes an API token by POSTing a dummy multipart upload to the legacy
+// upload endpoint. PyPI rejects the upload either way (we never send a real
+// package), but it distinguishes an authenticated-but-rejected token (403)
+// from an unauthenticated one (401), which is enough to tell the token apart
+// from a dead example value.
+func PyPI(ctx context.Context, token string) (types.VerificationResult, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField(":action", "file_upload"); err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build pypi multipart body: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return types.VerificationResult{}, fmt.Errorf("close pypi multipart body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://upload.pypi.org/legacy/", &buf)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build pypi request: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.SetBasicAuth("__token__", token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("pypi verify request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusForbidden:
+		return types.VerificationResult{Active: true, Details: "token authenticated, upload rejected (403)"}, nil
+	case http.StatusUnauthorized:
+		return types.VerificationResult{Active: false, Details: "token rejected (401)"}, nil
+	default:
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("unexpected status %d", resp.StatusCode)}, nil
+	}
+}
@@ -0,0 +1,43 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// Slack probes a Slack token by calling auth.test, which every valid token can
+// reach and which reports whether the token was revoked.
+func Slack(ctx context.Context, token string) (types.VerificationResult, error) {
+	form := url.Values{"token": {token}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/auth.test", strings.NewReader(form.Encode()))
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("slack verify request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return types.VerificationResult{}, fmt.Errorf("decode slack response: %w", err)
+	}
+
+	if body.OK {
+		return types.VerificationResult{Active: true, Details: "auth.test succeeded"}, nil
+	}
+	return types.VerificationResult{Active: false, Details: fmt.Sprintf("auth.test rejected token: %s", body.Error)}, nil
+}
@@ -0,0 +1,34 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// Stripe probes a Stripe API key with a HEAD request to /v1/charges, which
+// requires no request body and is rejected outright for an invalid key.
+func Stripe(ctx context.Context, key string) (types.VerificationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, "https://api.stripe.com/v1/charges", nil)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("build stripe request: %w", err)
+	}
+	req.SetBasicAuth(key, "")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return types.VerificationResult{}, fmt.Errorf("stripe verify request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return types.VerificationResult{Active: true, Details: "HEAD /v1/charges succeeded"}, nil
+	case http.StatusUnauthorized:
+		return types.VerificationResult{Active: false, Details: "key rejected (401)"}, nil
+	default:
+		return types.VerificationResult{Active: false, Details: fmt.Sprintf("unexpected status %d", resp.StatusCode)}, nil
+	}
+}
@@ -0,0 +1,129 @@
+// Package verifier probes secrets detected by the detector package against the
+// live provider APIs that issued them, so CI can distinguish a dead example
+// credential from one that still grants access.
+package verifier
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hadnu/cicd-secret-detector/internal/detector"
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+// DefaultTimeout bounds how long a single verifier is allowed to spend probing
+// a provider API.
+const DefaultTimeout = 10 * time.Second
+
+// maxWorkers caps how many verification probes run concurrently. This pool is
+// separate from the file-scan worker pool in the scanner package.
+const maxWorkers = 10
+
+// httpClient is shared by all HTTP-based verifiers; each call still applies its
+// own context deadline via DefaultTimeout.
+var httpClient = &http.Client{}
+
+// WithDefaults attaches the built-in Verify funcs (GitHub, Slack, PyPI, Stripe,
+// GCP) to the patterns whose Name matches a known provider. AWS keys are
+// verified as a pair (access key ID + secret) rather than per-pattern, see
+// verifyAWSPairs. Patterns are returned in the same order; unrecognized names
+// are left as-is.
+func WithDefaults(patterns []detector.Pattern) []detector.Pattern {
+	out := make([]detector.Pattern, len(patterns))
+	for i, p := range patterns {
+		if v, ok := defaultVerifiers[p.Name]; ok {
+			p.Verify = v
+		}
+		out[i] = p
+	}
+	return out
+}
+
+var defaultVerifiers = map[string]func(ctx context.Context, match string) (types.VerificationResult, error){
+	"GitHub Personal Access Token": GitHub,
+	"Slack Token":                  Slack,
+	"PyPI API Token":               PyPI,
+	"Stripe API Key":               Stripe,
+	"GCP Service Account Key":      GCPServiceAccountKey,
+}
+
+// Verify probes every finding that has a known verifier for its SecretType,
+// de-duplicating identical secret values so the same credential is never
+// probed twice, and returns findings with Verified/VerificationError/VerifiedAt
+// populated. Findings with no matching verifier are returned unchanged.
+func Verify(ctx context.Context, findings []types.Finding, patterns []detector.Pattern) []types.Finding {
+	verifyByName := make(map[string]func(context.Context, string) (types.VerificationResult, error), len(patterns))
+	for _, p := range patterns {
+		if p.Verify != nil {
+			verifyByName[p.Name] = p.Verify
+		}
+	}
+
+	type job struct {
+		indices []int
+		verify  func(context.Context, string) (types.VerificationResult, error)
+		value   string
+	}
+
+	seen := make(map[string]*job)
+	var jobs []*job
+	for i, f := range findings {
+		verify, ok := verifyByName[f.SecretType]
+		if !ok {
+			continue
+		}
+		key := f.SecretType + "\x00" + f.Value
+		j, ok := seen[key]
+		if !ok {
+			j = &job{verify: verify, value: f.Value}
+			seen[key] = j
+			jobs = append(jobs, j)
+		}
+		j.indices = append(j.indices, i)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxWorkers)
+
+dispatch:
+	for _, j := range jobs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+
+		wg.Add(1)
+		go func(j *job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			runVerify(ctx, findings, j.indices, j.verify, j.value)
+		}(j)
+	}
+
+	wg.Wait()
+
+	verifyAWSPairs(ctx, findings)
+
+	return findings
+}
+
+// runVerify invokes verify with a bounded timeout and copies the outcome onto
+// every finding sharing this secret value.
+func runVerify(ctx context.Context, findings []types.Finding, indices []int, verify func(context.Context, string) (types.VerificationResult, error), value string) {
+	vctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	result, err := verify(vctx, value)
+	verifiedAt := time.Now()
+
+	for _, idx := range indices {
+		findings[idx].Verified = result.Active
+		findings[idx].VerifiedAt = verifiedAt
+		if err != nil {
+			findings[idx].VerificationError = err.Error()
+		}
+	}
+}
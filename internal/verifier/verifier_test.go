@@ -0,0 +1,84 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hadnu/cicd-secret-detector/internal/detector"
+	"github.com/hadnu/cicd-secret-detector/internal/types"
+)
+
+func TestVerify_DeduplicatesIdenticalSecrets(t *testing.T) {
+	var calls int32
+	patterns := []detector.Pattern{
+		{
+			Name: "Test Secret",
+			Verify: func(ctx context.Context, match string) (types.VerificationResult, error) {
+				atomic.AddInt32(&calls, 1)
+				return types.VerificationResult{Active: true}, nil
+			},
+		},
+	}
+
+	findings := []types.Finding{
+		{SecretType: "Test Secret", Value: "same-value", FilePath: "a.txt"},
+		{SecretType: "Test Secret", Value: "same-value", FilePath: "b.txt"},
+	}
+
+	got := Verify(context.Background(), findings, patterns)
+
+	if calls != 1 {
+		t.Errorf("verify func called %d times, want 1 (duplicate secret should be probed once)", calls)
+	}
+	for i, f := range got {
+		if !f.Verified {
+			t.Errorf("finding %d: Verified = false, want true", i)
+		}
+		if f.VerifiedAt.IsZero() {
+			t.Errorf("finding %d: VerifiedAt not set", i)
+		}
+	}
+}
+
+func TestVerify_SkipsPatternsWithoutVerifier(t *testing.T) {
+	patterns := []detector.Pattern{{Name: "No Verifier"}}
+	findings := []types.Finding{{SecretType: "No Verifier", Value: "x"}}
+
+	got := Verify(context.Background(), findings, patterns)
+
+	if got[0].Verified {
+		t.Error("Verified = true for a pattern with no Verify func, want false")
+	}
+	if !got[0].VerifiedAt.IsZero() {
+		t.Error("VerifiedAt set for a pattern with no Verify func, want zero")
+	}
+}
+
+func TestVerify_RecordsErrorWithoutLeakingTheSecret(t *testing.T) {
+	const rawSecret = "super-secret-value"
+
+	patterns := []detector.Pattern{
+		{
+			Name: "Flaky Secret",
+			Verify: func(ctx context.Context, match string) (types.VerificationResult, error) {
+				return types.VerificationResult{}, errors.New("provider unreachable")
+			},
+		},
+	}
+
+	findings := []types.Finding{{SecretType: "Flaky Secret", Value: rawSecret}}
+
+	got := Verify(context.Background(), findings, patterns)
+
+	if got[0].Verified {
+		t.Error("Verified = true despite verifier error, want false")
+	}
+	if got[0].VerificationError == "" {
+		t.Error("VerificationError not set after verifier error")
+	}
+	if got[0].VerificationError == rawSecret {
+		t.Error("VerificationError must never echo the raw secret value")
+	}
+}